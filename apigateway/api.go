@@ -4,21 +4,47 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/criyle/go-judge-demo/pb"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
 const maxLimit = 64 << 10 // 64k
 
 type api struct {
-	client pb.DemoBackendClient
+	client      pb.DemoBackendClient
+	submitLimit *rateLimiter
 }
 
 func (a *api) Register(r *gin.RouterGroup) {
 	r.GET("/submission", a.apiSubmission)
-	r.POST("/submit", a.apiSubmit)
+	r.GET("/submission/:id/events", a.apiSubmissionEvents)
+	r.POST("/submit", a.submitLimit.Middleware, a.apiSubmit)
+	r.POST("/submit/archive", a.submitLimit.Middleware, a.apiSubmitArchive)
+	r.POST("/run", a.submitLimit.Middleware, a.apiRun)
+
+	r.GET("/problem", a.apiProblems)
+	r.GET("/problem/:id", a.apiGetProblem)
+	r.POST("/problem", a.apiCreateProblem)
+	r.POST("/problem/import", a.apiImportProblem)
+	r.PUT("/problem/:id", a.apiUpdateProblem)
+	r.POST("/problem/:id/build", a.apiBuildProblem)
+
+	r.GET("/shell/sessions", a.apiShellSessions)
+	r.GET("/shell/sessions/:id", a.apiShellSession)
+	r.GET("/shell/live", a.apiLiveShells)
+	r.DELETE("/shell/live/:id", a.apiKillShell)
+	r.GET("/shell/profiles", a.apiShellProfiles)
+	r.GET("/shell/files", a.apiShellFiles)
+	r.PUT("/shell/files/*name", a.apiUploadShellFile)
+	r.DELETE("/shell/files/*name", a.apiDeleteShellFile)
+	r.GET("/shell/archive/:id", a.apiShellArchive)
+	r.GET("/shell/workspace", a.apiShellWorkspace)
+	r.DELETE("/shell/workspace", a.apiResetShellWorkspace)
 }
 
 func (a *api) apiSubmission(c *gin.Context) {
@@ -56,9 +82,56 @@ func (a *api) apiSubmit(c *gin.Context) {
 		return
 	}
 	resp, err := a.client.Submit(c, &req)
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// apiRun submits and waits for the result, it responds 202 with the latest update
+// if not finished in `timeout` seconds
+func (a *api) apiRun(c *gin.Context) {
+	var req pb.SubmitRequest
+	if !readProto(c, &req, maxLimit) {
+		return
+	}
+	timeout, _ := strconv.ParseUint(c.Query("timeout"), 10, 32)
+	t := uint32(timeout)
+	resp, err := a.client.Run(c, pb.RunRequest_builder{
+		Submit:  &req,
+		Timeout: &t,
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	ct, err := protojson.Marshal(resp)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
-	c.JSON(http.StatusOK, resp)
+	code := http.StatusOK
+	if resp.GetType() != updateFinished {
+		code = http.StatusAccepted
+	}
+	c.Data(code, "application/json; charset=utf-8", ct)
+}
+
+// grpcHTTPStatus maps the gRPC status returned from demo server into HTTP status
+func grpcHTTPStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
 }
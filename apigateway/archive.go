@@ -0,0 +1,315 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	maxArchiveSize         = 1 << 20 // 1m compressed upload
+	maxArchiveUncompressed = 2 << 20 // 2m in total after extraction, stays under gRPC message limit
+	maxArchiveFiles        = 256
+
+	manifestFileName = "manifest.json"
+)
+
+var (
+	errArchiveTooLarge   = errors.New("archive: uncompressed size too large")
+	errArchiveTooMany    = errors.New("archive: too many files")
+	errArchiveFormat     = errors.New("archive: unknown format, expect zip or tar.gz")
+	errArchiveNoManifest = errors.New("archive: manifest not found")
+)
+
+// archiveManifest describes how the files inside an archive form a submission
+type archiveManifest struct {
+	Language json.RawMessage `json:"language"`
+	Entry    string          `json:"entry"`
+	Tests    []archiveTest   `json:"tests"`
+}
+
+// archiveTest references input / answer files inside the archive
+type archiveTest struct {
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+// apiSubmitArchive accepts a multipart form with an `archive` file (zip or tar.gz)
+// and an optional `manifest` field. If the manifest field is absent, manifest.json
+// at the root of the archive is used.
+func (a *api) apiSubmitArchive(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxArchiveSize+maxLimit)
+	fh, err := c.FormFile("archive")
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	if fh.Size > maxArchiveSize {
+		c.AbortWithStatusJSON(http.StatusBadRequest,
+			fmt.Sprintf("Upload size too large: %d", fh.Size))
+		return
+	}
+	files, err := readArchiveFile(fh)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	manifest := []byte(c.PostForm("manifest"))
+	if len(manifest) == 0 {
+		m, ok := files[manifestFileName]
+		if !ok {
+			c.AbortWithError(http.StatusBadRequest, errArchiveNoManifest)
+			return
+		}
+		manifest = m
+	}
+	// the manifest file is never a source file, even if the manifest field is given
+	delete(files, manifestFileName)
+	req, err := archiveToSubmitRequest(manifest, files)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	resp, err := a.client.Submit(c, req)
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func readArchiveFile(fh *multipart.FileHeader) (map[string][]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxArchiveSize))
+	if err != nil {
+		return nil, err
+	}
+	return readArchive(content)
+}
+
+// readArchive extracts a zip or tar.gz archive into memory, detected by its magic bytes
+func readArchive(content []byte) (map[string][]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, []byte("PK\x03\x04")):
+		return readZip(content)
+	case bytes.HasPrefix(content, []byte{0x1f, 0x8b}):
+		return readTarGz(content)
+	default:
+		return nil, errArchiveFormat
+	}
+}
+
+func readZip(content []byte) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+	if len(zr.File) > maxArchiveFiles {
+		return nil, errArchiveTooMany
+	}
+	ar := newArchiveReader()
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			return nil, fmt.Errorf("archive: %q is not a regular file", zf.Name)
+		}
+		r, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = ar.add(zf.Name, r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ar.files, nil
+}
+
+func readTarGz(content []byte) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	ar := newArchiveReader()
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir, tar.TypeXGlobalHeader:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("archive: %q is not a regular file", hdr.Name)
+		}
+		if err := ar.add(hdr.Name, tr); err != nil {
+			return nil, err
+		}
+	}
+	return ar.files, nil
+}
+
+// archiveReader enforces the path and size restrictions while extracting.
+// Sizes declared in archive headers are not trusted, the actual bytes read are counted.
+type archiveReader struct {
+	files     map[string][]byte
+	remaining int64
+}
+
+func newArchiveReader() *archiveReader {
+	return &archiveReader{
+		files:     make(map[string][]byte),
+		remaining: maxArchiveUncompressed,
+	}
+}
+
+func (a *archiveReader) add(name string, r io.Reader) error {
+	p, err := cleanArchivePath(name)
+	if err != nil {
+		return err
+	}
+	if _, ok := a.files[p]; ok {
+		return fmt.Errorf("archive: duplicated file %q", p)
+	}
+	if len(a.files) >= maxArchiveFiles {
+		return errArchiveTooMany
+	}
+	c, err := io.ReadAll(io.LimitReader(r, a.remaining+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(c)) > a.remaining {
+		return errArchiveTooLarge
+	}
+	a.remaining -= int64(len(c))
+	a.files[p] = c
+	return nil
+}
+
+// cleanArchivePath rejects absolute paths and any path escaping the archive root
+func cleanArchivePath(name string) (string, error) {
+	if name == "" || strings.ContainsRune(name, 0) || strings.Contains(name, "\\") {
+		return "", fmt.Errorf("archive: invalid file name %q", name)
+	}
+	if path.IsAbs(name) || (len(name) > 1 && name[1] == ':') {
+		return "", fmt.Errorf("archive: absolute path %q", name)
+	}
+	for _, e := range strings.Split(name, "/") {
+		if e == ".." {
+			return "", fmt.Errorf("archive: path traversal %q", name)
+		}
+	}
+	p := path.Clean(name)
+	if p == "." {
+		return "", fmt.Errorf("archive: invalid file name %q", name)
+	}
+	return p, nil
+}
+
+// archiveToSubmitRequest converts the extracted files into a multi-file SubmitRequest.
+// Test files referenced by the manifest are removed from the source files.
+func archiveToSubmitRequest(manifest []byte, files map[string][]byte) (*pb.SubmitRequest, error) {
+	var m archiveManifest
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	lang := new(pb.Language)
+	if len(m.Language) > 0 {
+		if err := protojson.Unmarshal(m.Language, lang); err != nil {
+			return nil, fmt.Errorf("manifest: language: %w", err)
+		}
+	}
+	if m.Entry == "" {
+		m.Entry = lang.GetSourceFileName()
+	}
+	entry, err := cleanArchivePath(m.Entry)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: entry: %w", err)
+	}
+	source, ok := files[entry]
+	if !ok {
+		return nil, fmt.Errorf("manifest: entry %q not found in archive", entry)
+	}
+	lang.SetSourceFileName(entry)
+
+	inputAnswer := make([]*pb.InputAnswer, 0, len(m.Tests))
+	tests := make(map[string]bool)
+	for i, t := range m.Tests {
+		in, err := archiveTestFile(files, t.Input)
+		if err != nil {
+			return nil, fmt.Errorf("manifest: test %d input: %w", i, err)
+		}
+		ans, err := archiveTestFile(files, t.Answer)
+		if err != nil {
+			return nil, fmt.Errorf("manifest: test %d answer: %w", i, err)
+		}
+		inputAnswer = append(inputAnswer, pb.InputAnswer_builder{
+			Input:  &in,
+			Answer: &ans,
+		}.Build())
+		tests[path.Clean(t.Input)] = true
+		tests[path.Clean(t.Answer)] = true
+	}
+
+	srcFiles := make([]*pb.SourceFile, 0, len(files))
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if name == entry || tests[name] {
+			continue
+		}
+		srcFiles = append(srcFiles, pb.SourceFile_builder{
+			Name:    &name,
+			Content: files[name],
+		}.Build())
+	}
+
+	src := string(source)
+	return pb.SubmitRequest_builder{
+		Language:    lang,
+		Source:      &src,
+		InputAnswer: inputAnswer,
+		Files:       srcFiles,
+	}.Build(), nil
+}
+
+func archiveTestFile(files map[string][]byte, name string) (string, error) {
+	p, err := cleanArchivePath(name)
+	if err != nil {
+		return "", err
+	}
+	c, ok := files[p]
+	if !ok {
+		return "", fmt.Errorf("%q not found in archive", p)
+	}
+	return string(c), nil
+}
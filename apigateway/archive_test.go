@@ -0,0 +1,169 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+type archiveEntry struct {
+	name    string
+	content []byte
+	symlink bool
+}
+
+func makeZip(t *testing.T, entries []archiveEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.symlink {
+			hdr.SetMode(fs.ModeSymlink | 0o777)
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(e.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func makeTarGz(t *testing.T, entries []archiveEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.symlink {
+			hdr = &tar.Header{Name: e.name, Linkname: string(e.content), Mode: 0o777, Typeflag: tar.TypeSymlink}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if !e.symlink {
+			if _, err := tw.Write(e.content); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCleanArchivePath(t *testing.T) {
+	tests := []struct {
+		name string
+		want string // empty if rejected
+	}{
+		{"main.cc", "main.cc"},
+		{"src/a.h", "src/a.h"},
+		{"./src//a.h", "src/a.h"},
+		{"", ""},
+		{".", ""},
+		{"./", ""},
+		{"..", ""},
+		{"../etc/passwd", ""},
+		{"src/../../a", ""},
+		{"src/..", ""},
+		{"/etc/passwd", ""},
+		{"C:/Windows/a", ""},
+		{"c:a", ""},
+		{"..\\a", ""},
+		{"a\x00b", ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.name), func(t *testing.T) {
+			got, err := cleanArchivePath(tt.name)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("cleanArchivePath(%q) = %q, want error", tt.name, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("cleanArchivePath(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadArchive(t *testing.T) {
+	many := make([]archiveEntry, maxArchiveFiles+1)
+	for i := range many {
+		many[i] = archiveEntry{name: fmt.Sprintf("f%d", i), content: []byte("x")}
+	}
+	split := []archiveEntry{
+		{name: "a", content: make([]byte, maxArchiveUncompressed/2)},
+		{name: "b", content: make([]byte, maxArchiveUncompressed/2+1)},
+	}
+	tests := []struct {
+		name    string
+		entries []archiveEntry
+		err     error // nil to check for any error if wantErr
+		wantErr bool
+	}{
+		{name: "ok", entries: []archiveEntry{{name: "main.cc", content: []byte("int main(){}")}, {name: "src/a.h"}}},
+		{name: "parent", entries: []archiveEntry{{name: "../a", content: []byte("x")}}, wantErr: true},
+		{name: "nested parent", entries: []archiveEntry{{name: "src/../../a", content: []byte("x")}}, wantErr: true},
+		{name: "absolute", entries: []archiveEntry{{name: "/etc/passwd", content: []byte("x")}}, wantErr: true},
+		{name: "drive letter", entries: []archiveEntry{{name: "C:/a", content: []byte("x")}}, wantErr: true},
+		{name: "symlink", entries: []archiveEntry{{name: "link", content: []byte("/etc/passwd"), symlink: true}}, wantErr: true},
+		{name: "duplicated", entries: []archiveEntry{{name: "a"}, {name: "./a"}}, wantErr: true},
+		{name: "oversize", entries: []archiveEntry{{name: "big", content: make([]byte, maxArchiveUncompressed+1)}}, err: errArchiveTooLarge, wantErr: true},
+		{name: "oversize total", entries: split, err: errArchiveTooLarge, wantErr: true},
+		{name: "too many", entries: many, err: errArchiveTooMany, wantErr: true},
+	}
+	formats := []struct {
+		name string
+		make func(*testing.T, []archiveEntry) []byte
+	}{
+		{"zip", makeZip},
+		{"tar.gz", makeTarGz},
+	}
+	for _, f := range formats {
+		for _, tt := range tests {
+			t.Run(f.name+"/"+tt.name, func(t *testing.T) {
+				files, err := readArchive(f.make(t, tt.entries))
+				if !tt.wantErr {
+					if err != nil {
+						t.Fatalf("readArchive() error = %v", err)
+					}
+					if len(files) != len(tt.entries) {
+						t.Fatalf("readArchive() = %d files, want %d", len(files), len(tt.entries))
+					}
+					return
+				}
+				if err == nil {
+					t.Fatalf("readArchive() = %d files, want error", len(files))
+				}
+				if tt.err != nil && !errors.Is(err, tt.err) {
+					t.Fatalf("readArchive() error = %v, want %v", err, tt.err)
+				}
+			})
+		}
+	}
+}
+
+func TestReadArchiveFormat(t *testing.T) {
+	if _, err := readArchive([]byte("not an archive")); !errors.Is(err, errArchiveFormat) {
+		t.Fatalf("readArchive() error = %v, want %v", err, errArchiveFormat)
+	}
+}
@@ -1,36 +1,57 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/criyle/go-judge-demo/internal/judgeupdate"
 	"github.com/criyle/go-judge-demo/pb"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// updateResync tells the observers that some updates were lost and they should
+	// reload the submissions
+	updateResync = "resync"
+	// updateFinished is the last update of a submission
+	updateFinished = "finished"
 )
 
 type judgeUpdater struct {
 	client pb.DemoBackendClient
 	logger *zap.Logger
+	since  uint64 // last received seq, owned by getUpdateLoop
+	replay *replayBuffer
 
 	broadcast  chan *pb.JudgeUpdate
 	observers  map[*observer]bool
 	register   chan *observer
 	unregister chan *observer
+	subscribe  chan subscription
 }
 
-func newJudgeUpdater(client pb.DemoBackendClient, logger *zap.Logger) *judgeUpdater {
+func newJudgeUpdater(client pb.DemoBackendClient, logger *zap.Logger, replay int) *judgeUpdater {
 	ju := &judgeUpdater{
 		client:     client,
 		logger:     logger,
+		replay:     &replayBuffer{max: replay},
 		broadcast:  make(chan *pb.JudgeUpdate, 64),
 		observers:  make(map[*observer]bool),
 		register:   make(chan *observer, 64),
 		unregister: make(chan *observer, 64),
+		subscribe:  make(chan subscription, 64),
 	}
 	go ju.getUpdateLoop()
 	go ju.broadcastLoop()
@@ -54,16 +75,26 @@ func (j *judgeUpdater) getUpdateSingle() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	c, err := j.client.Updates(ctx, &emptypb.Empty{})
+	// resume from the last received update
+	c, err := j.client.Updates(ctx, pb.UpdatesRequest_builder{Since: &j.since}.Build())
 	if err != nil {
 		return err
 	}
 	for {
 		updates, err := c.Recv()
 		j.logger.Debug("update", zap.Any("updates", updates))
+		if status.Code(err) == codes.OutOfRange {
+			j.logger.Warn("updates lost, resync", zap.Uint64("since", j.since), zap.Error(err))
+			j.since = 0
+			j.broadcast <- pb.JudgeUpdate_builder{Type: proto.String(updateResync)}.Build()
+			return err
+		}
 		if err != nil {
 			return err
 		}
+		if updates.GetSeq() > 0 {
+			j.since = updates.GetSeq()
+		}
 		j.broadcast <- updates
 	}
 }
@@ -72,27 +103,60 @@ func (j *judgeUpdater) broadcastLoop() {
 	for {
 		select {
 		case c := <-j.register:
+			missed, ok := j.replay.Since(c.since)
+			if !ok {
+				missed = []*pb.JudgeUpdate{pb.JudgeUpdate_builder{Type: proto.String(updateResync)}.Build()}
+			}
+			msgs := make([]wsMessage, 0, len(missed))
+			for _, up := range missed {
+				if !c.match(up) {
+					continue
+				}
+				m, err := prepareUpdate(up)
+				if err != nil {
+					j.logger.Debug("encode fail", zap.Error(err))
+					continue
+				}
+				msgs = append(msgs, m)
+			}
+			c.replay <- msgs
 			j.observers[c] = true
 
 		case c := <-j.unregister:
 			delete(j.observers, c)
 
+		case s := <-j.subscribe:
+			if j.observers[s.o] {
+				s.o.apply(s.msg)
+			}
+
 		case msg := <-j.broadcast:
-			buf, err := protojson.Marshal(msg)
-			if err != nil {
-				j.logger.Debug("encode fail", zap.Error(err))
+			resync := msg.GetType() == updateResync
+			if resync {
+				j.replay.Reset()
+			} else {
+				j.replay.Add(msg)
+			}
+			if !resync && !j.hasObserver(msg) {
 				continue
 			}
-			pMsg, err := websocket.NewPreparedMessage(websocket.TextMessage, buf)
+			pMsg, err := prepareUpdate(msg)
 			if err != nil {
-				j.logger.Debug("prepare fail", zap.Error(err))
+				j.logger.Debug("encode fail", zap.Error(err))
 				continue
 			}
 			j.logger.Debug("#observer", zap.Int("count", len(j.observers)))
 			for o := range j.observers {
+				if !resync && !o.match(msg) {
+					continue
+				}
 				select {
 				case o.send <- pMsg:
 				default:
+					if !resync && msg.GetSeq() == 0 {
+						// queue positions are not replayed, drop instead
+						continue
+					}
 					j.logger.Debug("too slow")
 					// client too slow, stop it
 					delete(j.observers, o)
@@ -121,10 +185,14 @@ func (j *judgeUpdater) wsJudge(c *gin.Context) {
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
+	since, _ := strconv.ParseUint(c.Query("since"), 10, 64)
 	o := &observer{
-		ju:   j,
-		conn: conn,
-		send: make(chan *websocket.PreparedMessage, 64),
+		ju:     j,
+		conn:   conn,
+		send:   make(chan wsMessage, 64),
+		replay: make(chan []wsMessage, 1),
+		filter: queryFilter(c),
+		since:  since,
 	}
 	j.register <- o
 	go o.loop()
@@ -132,9 +200,16 @@ func (j *judgeUpdater) wsJudge(c *gin.Context) {
 }
 
 type observer struct {
-	ju   *judgeUpdater
-	conn *websocket.Conn
-	send chan *websocket.PreparedMessage
+	ju     *judgeUpdater
+	conn   *websocket.Conn
+	send   chan wsMessage
+	replay chan []wsMessage // missed updates since, sent on register
+
+	since   uint64 // from the url
+	lastSeq uint64 // last sent, owned by loop
+
+	// filter is nil to receive all updates, owned by broadcastLoop
+	filter *pb.UpdatesRequest
 }
 
 func (c *observer) pongLoop() {
@@ -142,17 +217,22 @@ func (c *observer) pongLoop() {
 		c.ju.unregister <- c
 		c.conn.Close()
 	}()
-	c.conn.SetReadLimit(512)
+	c.conn.SetReadLimit(maxSubscribeMessage)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
 		c.conn.SetReadDeadline(time.Now().Add(pongWait))
 		return nil
 	})
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, buf, err := c.conn.ReadMessage()
 		if err != nil {
 			break
 		}
+		msg := new(subscribeMessage)
+		if err := json.Unmarshal(buf, msg); err != nil {
+			continue
+		}
+		c.ju.subscribe <- subscription{o: c, msg: msg}
 	}
 }
 
@@ -163,16 +243,21 @@ func (c *observer) loop() {
 	ticker := time.NewTicker(pingPeriod)
 	defer ticker.Stop()
 
+	for _, m := range <-c.replay {
+		if err := c.write(m); err != nil {
+			return
+		}
+	}
 	for {
 		select {
 		case m, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, nil)
+				c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(
+					websocket.CloseTryAgainLater, fmt.Sprintf("too slow, reconnect with since=%d", c.lastSeq)))
 				return
 			}
-			err := c.conn.WritePreparedMessage(m)
-			if err != nil {
+			if err := c.write(m); err != nil {
 				// TODO: log err
 				return
 			}
@@ -185,3 +270,166 @@ func (c *observer) loop() {
 		}
 	}
 }
+
+func (c *observer) write(m wsMessage) error {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := c.conn.WritePreparedMessage(m.msg); err != nil {
+		return err
+	}
+	if m.seq > 0 {
+		c.lastSeq = m.seq
+	}
+	return nil
+}
+
+// wsMessage is an encoded update with its sequence number
+type wsMessage struct {
+	seq uint64
+	msg *websocket.PreparedMessage
+}
+
+func prepareUpdate(up *pb.JudgeUpdate) (wsMessage, error) {
+	buf, err := protojson.Marshal(up)
+	if err != nil {
+		return wsMessage{}, err
+	}
+	msg, err := websocket.NewPreparedMessage(websocket.TextMessage, buf)
+	if err != nil {
+		return wsMessage{}, err
+	}
+	return wsMessage{seq: up.GetSeq(), msg: msg}, nil
+}
+
+// replayBuffer keeps the latest updates received from the demo server so that
+// websocket clients could resume with `?since=<seq>`, owned by broadcastLoop
+type replayBuffer struct {
+	max   int
+	last  uint64 // last received seq
+	items []*pb.JudgeUpdate
+	next  int // ring position of the oldest once full
+}
+
+// Add stores the update, the "queued" updates without seq are not kept
+func (b *replayBuffer) Add(up *pb.JudgeUpdate) {
+	if up.GetSeq() == 0 {
+		return
+	}
+	b.last = up.GetSeq()
+	switch {
+	case b.max <= 0:
+	case len(b.items) < b.max:
+		b.items = append(b.items, up)
+	default:
+		b.items[b.next] = up
+		b.next = (b.next + 1) % b.max
+	}
+}
+
+// Reset drops the updates after resync with the demo server
+func (b *replayBuffer) Reset() {
+	b.last, b.items, b.next = 0, nil, 0
+}
+
+// Since returns the updates after the seq, or false if some of them are no longer
+// available
+func (b *replayBuffer) Since(since uint64) ([]*pb.JudgeUpdate, bool) {
+	if since == 0 || since == b.last {
+		return nil, true
+	}
+	ordered := append(slices.Clone(b.items[b.next:]), b.items[:b.next]...)
+	if since > b.last || len(ordered) == 0 || ordered[0].GetSeq() > since+1 {
+		return nil, false
+	}
+	i, _ := slices.BinarySearchFunc(ordered, since+1, func(up *pb.JudgeUpdate, seq uint64) int {
+		return cmp.Compare(up.GetSeq(), seq)
+	})
+	return ordered[i:], true
+}
+
+const maxSubscribeMessage = 4096
+
+// subscribeMessage is sent by the websocket client to receive only the matching
+// updates, fields are the same as the Updates filter:
+//
+//	{"type": "subscribe", "ids": ["<submission id>"], "userId": "", "language": ""}
+//
+// subscribe adds the ids and sets userId / language if not empty, unsubscribe
+// removes them. Before the first subscribe the client receives all updates.
+type subscribeMessage struct {
+	Type     string   `json:"type"`
+	IDs      []string `json:"ids"`
+	UserID   string   `json:"userId"`
+	Language string   `json:"language"`
+}
+
+type subscription struct {
+	o   *observer
+	msg *subscribeMessage
+}
+
+// queryFilter subscribes from the websocket url, e.g. `/api/ws/judge?id=<id>`
+func queryFilter(c *gin.Context) *pb.UpdatesRequest {
+	ids, userID, language := c.QueryArray("id"), c.Query("userId"), c.Query("language")
+	if len(ids) == 0 && userID == "" && language == "" {
+		return nil
+	}
+	return pb.UpdatesRequest_builder{Ids: ids, UserId: &userID, Language: &language}.Build()
+}
+
+func (c *observer) apply(msg *subscribeMessage) {
+	switch msg.Type {
+	case "subscribe":
+		if c.filter == nil {
+			c.filter = &pb.UpdatesRequest{}
+		}
+		ids := c.filter.GetIds()
+		for _, id := range msg.IDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		c.filter.SetIds(ids)
+		if msg.UserID != "" {
+			c.filter.SetUserId(msg.UserID)
+		}
+		if msg.Language != "" {
+			c.filter.SetLanguage(msg.Language)
+		}
+
+	case "unsubscribe":
+		if c.filter == nil {
+			c.filter = &pb.UpdatesRequest{}
+		}
+		c.filter.SetIds(slices.DeleteFunc(c.filter.GetIds(), func(id string) bool {
+			return slices.Contains(msg.IDs, id)
+		}))
+		if msg.UserID != "" && msg.UserID == c.filter.GetUserId() {
+			c.filter.ClearUserId()
+		}
+		if msg.Language != "" && msg.Language == c.filter.GetLanguage() {
+			c.filter.ClearLanguage()
+		}
+	}
+}
+
+// match reports whether the update should be sent, a client with an empty
+// subscription receives nothing
+func (c *observer) match(up *pb.JudgeUpdate) bool {
+	f := c.filter
+	if f == nil {
+		return true
+	}
+	if len(f.GetIds()) == 0 && f.GetUserId() == "" && f.GetLanguage() == "" {
+		return false
+	}
+	return judgeupdate.Match(f, up)
+}
+
+func (j *judgeUpdater) hasObserver(up *pb.JudgeUpdate) bool {
+	for o := range j.observers {
+		if o.match(up) {
+			return true
+		}
+	}
+	return false
+}
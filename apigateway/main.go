@@ -6,9 +6,12 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/criyle/go-judge-demo/internal/ratelimit"
+	"github.com/criyle/go-judge-demo/internal/tlsfile"
 	"github.com/criyle/go-judge-demo/pb"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/contrib/static"
@@ -21,7 +24,6 @@ import (
 	"go.uber.org/zap/zapgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
 )
 
@@ -29,6 +31,26 @@ const (
 	envToken          = "TOKEN"
 	envDemoServerAddr = "DEMO_SERVER"
 	envRelease        = "RELEASE"
+	envSessionSecret  = "SESSION_SECRET"
+	envTLSCert        = "TLS_CERT"
+	envTLSKey         = "TLS_KEY"
+	envTLSCA          = "TLS_CA"
+	envTLSServerName  = "TLS_SERVER_NAME"
+	envTrustedProxies = "TRUSTED_PROXIES"
+
+	envRateLimitSubmit   = "RATE_LIMIT_SUBMIT"
+	envRateLimitShell    = "RATE_LIMIT_SHELL"
+	envRateLimitLogin    = "RATE_LIMIT_LOGIN"
+	envShellMaxPerClient = "SHELL_MAX_PER_CLIENT"
+	envShellMax          = "SHELL_MAX"
+	envUpdateReplay      = "UPDATE_REPLAY"
+
+	defaultRateLimitSubmit   = "30/1m"
+	defaultRateLimitShell    = "10/1m"
+	defaultRateLimitLogin    = "10/1m"
+	defaultShellMaxPerClient = 2
+	defaultShellMax          = 50
+	defaultUpdateReplay      = 1024
 )
 
 func InterceptorLogger(l *zap.Logger) logging.Logger {
@@ -91,20 +113,27 @@ func main() {
 		srvAddr = e
 	}
 
+	tf, err := tlsfile.New(os.Getenv(envTLSCert), os.Getenv(envTLSKey), os.Getenv(envTLSCA))
+	if err != nil {
+		log.Fatalln("tls", err)
+	}
+
 	prom := grpc_prometheus.NewClientMetrics(grpc_prometheus.WithClientHandlingTimeHistogram())
 	prometheus.MustRegister(prom)
 	grpclog.SetLoggerV2(zapgrpc.NewLogger(logger))
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials()),
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(tlsfile.DialCredentials(tf, os.Getenv(envTLSServerName))),
 		grpc.WithChainUnaryInterceptor(
 			prom.UnaryClientInterceptor(),
 			logging.UnaryClientInterceptor(InterceptorLogger(logger)),
+			userUnaryInterceptor,
 		),
 		grpc.WithChainStreamInterceptor(
 			prom.StreamClientInterceptor(),
 			logging.StreamClientInterceptor(InterceptorLogger(logger)),
+			userStreamInterceptor,
 		)}
 	if token != "" {
-		opts = append(opts, grpc.WithPerRPCCredentials(newTokenAuth(token)))
+		opts = append(opts, grpc.WithPerRPCCredentials(newTokenAuth(token, tf != nil)))
 	}
 	conn, err := grpc.NewClient(srvAddr, opts...)
 	if err != nil {
@@ -113,20 +142,43 @@ func main() {
 	client := pb.NewDemoBackendClient(conn)
 
 	r := gin.New()
+	// X-Forwarded-For is only trusted from the configured proxies, otherwise
+	// clients could spoof the IP that rate limits and anonymous ownership use
+	var proxies []string
+	if p := os.Getenv(envTrustedProxies); p != "" {
+		proxies = strings.Split(p, ",")
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		log.Fatalln("trusted proxies", err)
+	}
 	r.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 	r.Use(ginzap.RecoveryWithZap(logger, true))
 
 	r.Use(static.ServeRoot("/", "dist"))
 	r.NoRoute(serveIndex)
 
+	sessionSecret := os.Getenv(envSessionSecret)
+	if sessionSecret == "" {
+		logger.Warn("SESSION_SECRET not set, sessions are invalidated on restart")
+	}
+	sessions := newSessions(client, sessionSecret)
+	sessions.limit = envRateLimiter(envRateLimitLogin, defaultRateLimitLogin)
+	r.Use(sessions.Middleware, forwardClientIP)
+
 	apiGroup := r.Group("/api")
-	api := &api{client: client}
+	api := &api{client: client, submitLimit: envRateLimiter(envRateLimitSubmit, defaultRateLimitSubmit)}
 	api.Register(apiGroup)
+	sessions.Register(apiGroup)
 
 	wsGroup := r.Group("/api/ws")
-	ju := newJudgeUpdater(client, logger)
+	ju := newJudgeUpdater(client, logger, envInt(envUpdateReplay, defaultUpdateReplay))
 	ju.Register(wsGroup)
-	sh := &shellHandle{client: client, logger: logger}
+	sh := &shellHandle{
+		client: client,
+		logger: logger,
+		limit:  envRateLimiter(envRateLimitShell, defaultRateLimitShell),
+		shells: ratelimit.NewConcurrency(envInt(envShellMaxPerClient, defaultShellMaxPerClient), envInt(envShellMax, defaultShellMax)),
+	}
 	sh.Register(wsGroup)
 
 	addr := ":5000"
@@ -140,6 +192,30 @@ func main() {
 	srv.ListenAndServe()
 }
 
+func envRateLimiter(name, def string) *rateLimiter {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		v = def
+	}
+	limit, burst, err := ratelimit.ParseRate(v)
+	if err != nil {
+		log.Fatalln(name, err)
+	}
+	return newRateLimiter(limit, burst)
+}
+
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalln(name, err)
+	}
+	return i
+}
+
 func serveIndex(c *gin.Context) {
 	if strings.HasPrefix(c.Request.URL.Path, "/api") {
 		c.AbortWithStatus(http.StatusNotImplemented)
@@ -149,11 +225,12 @@ func serveIndex(c *gin.Context) {
 }
 
 type tokenAuth struct {
-	token string
+	token  string
+	secure bool
 }
 
-func newTokenAuth(token string) credentials.PerRPCCredentials {
-	return &tokenAuth{token: token}
+func newTokenAuth(token string, secure bool) credentials.PerRPCCredentials {
+	return &tokenAuth{token: token, secure: secure}
 }
 
 // Return value is mapped to request headers.
@@ -163,6 +240,6 @@ func (t *tokenAuth) GetRequestMetadata(ctx context.Context, in ...string) (map[s
 	}, nil
 }
 
-func (*tokenAuth) RequireTransportSecurity() bool {
-	return false
+func (t *tokenAuth) RequireTransportSecurity() bool {
+	return t.secure
 }
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const maxProblemLimit = 3 << 20 // 3m, test data included
+
+func (a *api) apiProblems(c *gin.Context) {
+	id := c.Query("id")
+	resp, err := a.client.ListProblems(c, pb.ListProblemsRequest_builder{
+		Id: &id,
+	}.Build())
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func (a *api) apiGetProblem(c *gin.Context) {
+	id := c.Param("id")
+	testData := c.Query("testData") != ""
+	resp, err := a.client.GetProblem(c, pb.GetProblemRequest_builder{
+		Id:       &id,
+		TestData: &testData,
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func (a *api) apiCreateProblem(c *gin.Context) {
+	var req pb.Problem
+	if !readProto(c, &req, maxProblemLimit) {
+		return
+	}
+	resp, err := a.client.CreateProblem(c, &req)
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func (a *api) apiUpdateProblem(c *gin.Context) {
+	var req pb.Problem
+	if !readProto(c, &req, maxProblemLimit) {
+		return
+	}
+	req.SetId(c.Param("id"))
+	resp, err := a.client.UpdateProblem(c, &req)
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func (a *api) apiBuildProblem(c *gin.Context) {
+	var req pb.BuildProblemRequest
+	if !readProto(c, &req, maxProblemLimit) {
+		return
+	}
+	req.SetProblemId(c.Param("id"))
+	resp, err := a.client.BuildProblem(c, &req)
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// readProto reads protojson request body with size limit, aborts on failure
+func readProto(c *gin.Context, m proto.Message, limit int64) bool {
+	if c.Request.ContentLength > limit {
+		c.AbortWithStatusJSON(http.StatusBadRequest,
+			fmt.Sprintf("Upload size too large: %d", c.Request.ContentLength))
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, limit))
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return false
+	}
+	if err := protojson.Unmarshal(body, m); err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return false
+	}
+	return true
+}
+
+func writeProto(c *gin.Context, m proto.Message) {
+	ct, err := protojson.Marshal(m)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", ct)
+}
+
+// apiImportProblem accepts a multipart form with the zip `package` and an optional `id` to replace
+func (a *api) apiImportProblem(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxProblemLimit)
+	fh, err := c.FormFile("package")
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	id := c.PostForm("id")
+	resp, err := a.client.ImportProblem(c, pb.ImportProblemRequest_builder{
+		Package: content,
+		Id:      &id,
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/criyle/go-judge-demo/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// ctxClientIP is the gin context key of the client IP forwarded to demo server
+	ctxClientIP = "clientIP"
+	mdClientIP  = "x-client-ip"
+)
+
+// clientKey identifies the client by the logged in user, or by IP for anonymous clients
+func clientKey(c *gin.Context) string {
+	if u, ok := currentUser(c); ok {
+		return "user:" + u.ID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// forwardClientIP stores the client IP to be forwarded to demo server
+func forwardClientIP(c *gin.Context) {
+	c.Set(ctxClientIP, c.ClientIP())
+	c.Next()
+}
+
+// rateLimiter is a token bucket per client key
+type rateLimiter struct {
+	*ratelimit.Limiter
+}
+
+func newRateLimiter(limit rate.Limit, burst int) *rateLimiter {
+	return &rateLimiter{ratelimit.NewLimiter(limit, burst)}
+}
+
+// Middleware aborts with 429 and Retry-After if the client runs out of tokens
+func (l *rateLimiter) Middleware(c *gin.Context) {
+	if l.Unlimited() {
+		c.Next()
+		return
+	}
+	r := l.Get(clientKey(c)).Reserve()
+	if d := r.Delay(); d > 0 {
+		r.Cancel()
+		tooManyRequests(c, d)
+		return
+	}
+	c.Next()
+}
+
+func tooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, "Too many requests")
+}
@@ -2,40 +2,76 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/criyle/go-judge-demo/internal/ratelimit"
 	"github.com/criyle/go-judge-demo/pb"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 )
 
+// shellRetryAfter is suggested to clients reaching the concurrent shell cap
+const shellRetryAfter = 30 * time.Second
+
 type shellHandle struct {
 	client pb.DemoBackendClient
 	logger *zap.Logger
+	limit  *rateLimiter
+	shells *ratelimit.Concurrency
 }
 
 func (s *shellHandle) Register(r *gin.RouterGroup) {
-	r.GET("/shell", s.wsShell)
+	r.GET("/shell", s.limit.Middleware, s.wsShell)
 }
 
 func (s *shellHandle) wsShell(c *gin.Context) {
+	release, err := s.shells.Acquire(clientKey(c))
+	if err != nil {
+		tooManyRequests(c, shellRetryAfter)
+		return
+	}
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
+		release()
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
 	ctx, cancel := context.WithCancel(c)
 	sc, err := s.client.Shell(ctx)
+	if err == nil {
+		// empty session starts a new shell, others attach read-only by default
+		// unless reattaching as the owner with the resume token
+		session, token, profile := c.Query("session"), c.Query("token"), c.Query("profile")
+		write := session == "" || token != "" || c.Query("write") == "true"
+		offset, _ := strconv.ParseUint(c.Query("offset"), 10, 64)
+		err = sc.Send(pb.ShellInput_builder{
+			Attach: pb.ShellAttach_builder{
+				SessionId: &session,
+				Write:     &write,
+				Token:     &token,
+				Offset:    &offset,
+				Profile:   &profile,
+			}.Build(),
+		}.Build())
+	}
 	if err != nil {
 		conn.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, "shell error: %v", err))
 		conn.WriteMessage(websocket.CloseMessage, nil)
 		conn.Close()
 		cancel()
+		release()
 		return
 	}
+	go func() {
+		<-ctx.Done()
+		release()
+	}()
 	sh := &shell{
 		conn:   conn,
 		sc:     sc,
@@ -67,16 +103,26 @@ func (s *shell) readLoop() {
 		return nil
 	})
 	for {
-		_, msg, err := s.conn.ReadMessage()
+		mt, msg, err := s.conn.ReadMessage()
 		if err != nil {
 			break
 		}
-		err = s.sc.Send(pb.ShellInput_builder{
-			Input: pb.Input_builder{
-				Content: msg,
-			}.Build(),
-		}.Build())
-		if err != nil {
+		var in *pb.ShellInput
+		switch mt {
+		case websocket.BinaryMessage:
+			in = shellInput(msg)
+
+		case websocket.TextMessage:
+			in, err = shellControlInput(msg)
+			if err != nil {
+				s.logger.Debug("invalid shell control", zap.ByteString("message", msg), zap.Error(err))
+				continue
+			}
+		}
+		if in == nil {
+			continue
+		}
+		if err := s.sc.Send(in); err != nil {
 			break
 		}
 	}
@@ -104,6 +150,14 @@ func (s *shell) writeLoop() {
 				s.conn.WriteMessage(websocket.CloseMessage, nil)
 				return
 			}
+			for _, ctl := range shellOutputControl(msg) {
+				if err := s.conn.WriteMessage(websocket.TextMessage, ctl); err != nil {
+					return
+				}
+			}
+			if len(msg.GetContent()) == 0 {
+				continue
+			}
 			err := s.conn.WriteMessage(websocket.BinaryMessage, msg.GetContent())
 			if err != nil {
 				return
@@ -125,3 +179,164 @@ func (s *shell) recvLoop() {
 		s.msg <- msg
 	}
 }
+
+// shellControl is the text frame on the shell websocket, binary frames are the
+// terminal input:
+//
+//	{"type": "resize", "rows": 24, "cols": 80}
+//	{"type": "signal", "signal": "INT"}
+//	{"type": "share", "write": true}
+//	{"type": "upload", "name": "a.txt", "content": "<base64>"}
+//	{"type": "download", "name": "a.txt"}
+type shellControl struct {
+	Type    string `json:"type"`
+	Rows    uint32 `json:"rows"`
+	Cols    uint32 `json:"cols"`
+	X       uint32 `json:"x"` // pixels
+	Y       uint32 `json:"y"`
+	Signal  string `json:"signal"`
+	Write   bool   `json:"write"`
+	Name    string `json:"name"`    // of the transferred file
+	Content []byte `json:"content"` // uploaded
+}
+
+// shellSessionMessage is the text frame sent once attached, other clients attach
+// to the session with ?session=<id>&write=<bool>, and the owner reattaches with
+// ?session=<id>&token=<token>&offset=<output bytes received>. Offset is where
+// the following output starts.
+type shellSessionMessage struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Write    bool   `json:"write"`
+	Token    string `json:"token,omitempty"`
+	Offset   uint64 `json:"offset"`
+	RecordID string `json:"recordId"` // for the archive download after exit
+}
+
+// shellNoticeMessage is the text frame of a message to show, e.g. idle warning
+type shellNoticeMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// shellTransferMessage is the text frame replied to an upload or download, with
+// the downloaded content or the error
+type shellTransferMessage struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Download bool   `json:"download"`
+	Content  []byte `json:"content,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// shellExitMessage is the last text frame once the session ended, status is the
+// exec result status or the reason the session was closed
+type shellExitMessage struct {
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	ExitStatus int32  `json:"exitStatus"`
+	Time       uint64 `json:"time"`   // ms
+	Memory     uint64 `json:"memory"` // kb
+}
+
+// shellOutputControl returns the text frames of the output other than content
+func shellOutputControl(msg *pb.ShellOutput) [][]byte {
+	var rt [][]byte
+	if msg.GetSessionId() != "" {
+		buf, _ := json.Marshal(shellSessionMessage{
+			Type:     "session",
+			ID:       msg.GetSessionId(),
+			Write:    msg.GetWrite(),
+			Token:    msg.GetToken(),
+			Offset:   msg.GetOffset(),
+			RecordID: msg.GetRecordId(),
+		})
+		rt = append(rt, buf)
+	}
+	if msg.GetNotice() != "" {
+		buf, _ := json.Marshal(shellNoticeMessage{Type: "notice", Message: msg.GetNotice()})
+		rt = append(rt, buf)
+	}
+	if t := msg.GetTransfer(); t != nil {
+		buf, _ := json.Marshal(shellTransferMessage{
+			Type:     "transfer",
+			Name:     t.GetName(),
+			Download: t.GetDownload(),
+			Content:  t.GetContent(),
+			Error:    t.GetError(),
+		})
+		rt = append(rt, buf)
+	}
+	if e := msg.GetExit(); e != nil {
+		buf, _ := json.Marshal(shellExitMessage{
+			Type:       "exit",
+			Status:     e.GetStatus(),
+			ExitStatus: e.GetExitStatus(),
+			Time:       e.GetTime(),
+			Memory:     e.GetMemory(),
+		})
+		rt = append(rt, buf)
+	}
+	return rt
+}
+
+// signalInput is the terminal control character which the tty translates into
+// the signal of the foreground process
+var signalInput = map[string][]byte{
+	"INT":  {0x03}, // ^C
+	"QUIT": {0x1c}, // ^\
+	"TSTP": {0x1a}, // ^Z
+	"EOF":  {0x04}, // ^D
+}
+
+func shellInput(content []byte) *pb.ShellInput {
+	return pb.ShellInput_builder{
+		Input: pb.Input_builder{
+			Content: content,
+		}.Build(),
+	}.Build()
+}
+
+func shellControlInput(msg []byte) (*pb.ShellInput, error) {
+	var ctl shellControl
+	if err := json.Unmarshal(msg, &ctl); err != nil {
+		return nil, err
+	}
+	switch ctl.Type {
+	case "resize":
+		if ctl.Rows == 0 || ctl.Cols == 0 {
+			return nil, fmt.Errorf("invalid size %dx%d", ctl.Cols, ctl.Rows)
+		}
+		return pb.ShellInput_builder{
+			Resize: pb.Resize_builder{
+				Rows: &ctl.Rows,
+				Cols: &ctl.Cols,
+				X:    &ctl.X,
+				Y:    &ctl.Y,
+			}.Build(),
+		}.Build(), nil
+
+	case "signal":
+		c, ok := signalInput[strings.ToUpper(strings.TrimPrefix(ctl.Signal, "SIG"))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported signal %q", ctl.Signal)
+		}
+		return shellInput(c), nil
+
+	case "share":
+		return pb.ShellInput_builder{
+			Share: pb.ShellShare_builder{Write: &ctl.Write}.Build(),
+		}.Build(), nil
+
+	case "upload", "download":
+		download := ctl.Type == "download"
+		return pb.ShellInput_builder{
+			Transfer: pb.ShellTransfer_builder{
+				Name:     &ctl.Name,
+				Download: &download,
+				Content:  ctl.Content,
+			}.Build(),
+		}.Build(), nil
+	}
+	return nil, fmt.Errorf("unknown control type %q", ctl.Type)
+}
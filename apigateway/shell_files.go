@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/gin-gonic/gin"
+)
+
+const maxShellUpload = 16 << 20 // 16m, the backend keeps its own total limit
+
+func (a *api) apiShellFiles(c *gin.Context) {
+	resp, err := a.client.ListShellFiles(c, pb.ListShellFilesRequest_builder{}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// apiUploadShellFile uploads the request body as the file copied into the new
+// shells
+func (a *api) apiUploadShellFile(c *gin.Context) {
+	if c.Request.ContentLength > maxShellUpload {
+		c.AbortWithStatusJSON(http.StatusBadRequest,
+			fmt.Sprintf("Upload size too large: %d", c.Request.ContentLength))
+		return
+	}
+	content, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxShellUpload))
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	name := strings.TrimPrefix(c.Param("name"), "/")
+	resp, err := a.client.UploadShellFile(c, pb.ShellFile_builder{
+		Name:    &name,
+		Content: content,
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func (a *api) apiDeleteShellFile(c *gin.Context) {
+	name := strings.TrimPrefix(c.Param("name"), "/")
+	resp, err := a.client.DeleteShellFile(c, pb.DeleteShellFileRequest_builder{Name: &name}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// apiShellArchive downloads the tar archive of the working directory of the
+// recorded session after the shell exited
+func (a *api) apiShellArchive(c *gin.Context) {
+	id := c.Param("id")
+	gs, err := a.client.GetShellArchive(c, pb.GetShellArchiveRequest_builder{Id: &id}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	first, err := gs.Recv()
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	c.Header("Content-Type", "application/x-tar")
+	c.Header("Content-Length", fmt.Sprint(first.GetSize()))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".tar"))
+	c.Status(http.StatusOK)
+	for chunk := first; ; {
+		if _, err := c.Writer.Write(chunk.GetContent()); err != nil {
+			return
+		}
+		if chunk, err = gs.Recv(); err != nil {
+			// the response is already started, the client sees a short body
+			return
+		}
+	}
+}
+
+func (a *api) apiShellProfiles(c *gin.Context) {
+	resp, err := a.client.ListShellProfiles(c, pb.ListShellProfilesRequest_builder{}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+func (a *api) apiShellWorkspace(c *gin.Context) {
+	resp, err := a.client.GetShellWorkspace(c, pb.GetShellWorkspaceRequest_builder{}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// apiResetShellWorkspace empties the workspace restored in the new shells
+func (a *api) apiResetShellWorkspace(c *gin.Context) {
+	resp, err := a.client.ResetShellWorkspace(c, pb.ResetShellWorkspaceRequest_builder{}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
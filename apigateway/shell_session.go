@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+func (a *api) apiShellSessions(c *gin.Context) {
+	resp, err := a.client.ListShellSessions(c, pb.ListShellSessionsRequest_builder{
+		Id:     proto.String(c.Query("id")),
+		UserId: proto.String(c.Query("userId")),
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// apiShellSession responds the session metadata, or the recording as asciicast v2
+// file for `<id>.cast`
+func (a *api) apiShellSession(c *gin.Context) {
+	id, cast := strings.CutSuffix(c.Param("id"), ".cast")
+	gs, err := a.client.GetShellSession(c, pb.GetShellSessionRequest_builder{Id: &id}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	first, err := gs.Recv()
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	ss := first.GetSession()
+	if !cast {
+		writeProto(c, ss)
+		return
+	}
+
+	c.Header("Content-Type", "application/x-asciicast")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".cast"))
+	c.Status(http.StatusOK)
+	enc := json.NewEncoder(c.Writer)
+	enc.SetEscapeHTML(false)
+	enc.Encode(asciicastHeader{
+		Version:   2,
+		Width:     ss.GetWidth(),
+		Height:    ss.GetHeight(),
+		Timestamp: ss.GetStart().GetSeconds(),
+		Env:       asciicastEnv(ss),
+	})
+	for {
+		chunk, err := gs.Recv()
+		if err != nil {
+			// the response is already started, the truncated file is still playable
+			return
+		}
+		for _, e := range chunk.GetEvents() {
+			if err := enc.Encode([]any{e.GetTime(), e.GetType(), e.GetData()}); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// asciicastHeader is the first line of asciicast v2 file
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint32            `json:"width"`
+	Height    uint32            `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// asciicastEnv returns the TERM and SHELL of the session profile, sessions
+// recorded before the command was kept have the default TERM only
+func asciicastEnv(ss *pb.ShellSession) map[string]string {
+	env := map[string]string{"TERM": "xterm-256color"}
+	if t := ss.GetTerm(); t != "" {
+		env["TERM"] = t
+	}
+	if cmd := ss.GetCommand(); len(cmd) > 0 {
+		env["SHELL"] = cmd[0]
+	}
+	return env
+}
+
+func (a *api) apiLiveShells(c *gin.Context) {
+	resp, err := a.client.ListLiveShells(c, pb.ListLiveShellsRequest_builder{
+		UserId: proto.String(c.Query("userId")),
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
+
+// apiKillShell terminates the running shell and responds its last state
+func (a *api) apiKillShell(c *gin.Context) {
+	resp, err := a.client.KillShell(c, pb.KillShellRequest_builder{
+		SessionId: proto.String(c.Param("id")),
+	}.Build())
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	writeProto(c, resp)
+}
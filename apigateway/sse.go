@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const sseHeartbeat = 15 * time.Second
+
+// apiSubmissionEvents streams the updates of one submission as server-sent events
+// with the update seq as event id, so that the client resumes with Last-Event-ID.
+// The stream ends after the finished update, which is sent at once if the
+// submission was already judged.
+func (a *api) apiSubmissionEvents(c *gin.Context) {
+	id := c.Param("id")
+	since, _ := strconv.ParseUint(c.GetHeader("Last-Event-ID"), 10, 64)
+
+	// gin context is not canceled with the request, but it carries the user for
+	// the metadata
+	ctx, cancel := context.WithCancel(c)
+	defer cancel()
+	defer context.AfterFunc(c.Request.Context(), cancel)()
+
+	us, sub, err := a.subscribe(ctx, id, since)
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for {
+		if sub.GetType() == updateFinished {
+			if err := writeEvent(c, finishedUpdate(sub)); err != nil {
+				c.Error(err)
+			}
+			return
+		}
+		err := streamEvents(ctx, c, us)
+		if status.Code(err) != codes.OutOfRange {
+			if err != nil && ctx.Err() == nil {
+				c.Error(err)
+			}
+			return
+		}
+		// the missed updates are gone, reset the event id and continue live
+		fmt.Fprint(c.Writer, "id\ndata: {\"type\":\"resync\"}\n\n")
+		c.Writer.Flush()
+		if us, sub, err = a.subscribe(ctx, id, 0); err != nil {
+			if ctx.Err() == nil {
+				c.Error(err)
+			}
+			return
+		}
+	}
+}
+
+// subscribe opens the updates of the submission and then reads its state, so that
+// it could not finish in between unnoticed. The header of the stream is sent after
+// the observer is registered, failures are left to Recv.
+func (a *api) subscribe(ctx context.Context, id string, since uint64) (pb.DemoBackend_UpdatesClient, *pb.Submission, error) {
+	us, err := a.client.Updates(ctx, pb.UpdatesRequest_builder{
+		Ids:   []string{id},
+		Since: &since,
+	}.Build())
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := us.Header(); err != nil {
+		return nil, nil, err
+	}
+	sub, err := a.client.GetSubmission(ctx, pb.GetSubmissionRequest_builder{Id: &id}.Build())
+	if err != nil {
+		return nil, nil, err
+	}
+	return us, sub, nil
+}
+
+func streamEvents(ctx context.Context, c *gin.Context, us pb.DemoBackend_UpdatesClient) error {
+	updates := make(chan *pb.JudgeUpdate)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			u, err := us.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case updates <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(sseHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err := <-errCh:
+			return err
+
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+
+		case u := <-updates:
+			if err := writeEvent(c, u); err != nil {
+				return err
+			}
+			if u.GetType() == updateFinished {
+				return nil
+			}
+		}
+	}
+}
+
+func writeEvent(c *gin.Context, u *pb.JudgeUpdate) error {
+	buf, err := protojson.Marshal(u)
+	if err != nil {
+		return err
+	}
+	if u.GetSeq() > 0 {
+		fmt.Fprintf(c.Writer, "id: %d\n", u.GetSeq())
+	}
+	fmt.Fprintf(c.Writer, "data: %s\n\n", buf)
+	c.Writer.Flush()
+	return nil
+}
+
+// finishedUpdate is the final state of the judged submission as its finished update
+func finishedUpdate(sub *pb.Submission) *pb.JudgeUpdate {
+	return pb.JudgeUpdate_builder{
+		Id:       proto.String(sub.GetId()),
+		Type:     proto.String(updateFinished),
+		Status:   proto.String(sub.GetStatus()),
+		Date:     sub.GetDate(),
+		Language: sub.GetLanguage(),
+		Results:  sub.GetResults(),
+		UserId:   proto.String(sub.GetUserId()),
+	}.Build()
+}
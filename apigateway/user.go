@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	sessionCookie = "session"
+	sessionMaxAge = 7 * 24 * time.Hour
+	userRolesTTL  = 30 * time.Second // roles are resolved again after
+
+	// ctxUser is the gin context key of the logged in *session
+	ctxUser = "user"
+
+	// metadata keys to forward the logged in user to demo server
+	mdUserID    = "x-user-id"
+	mdUserName  = "x-user-name"
+	mdUserRoles = "x-user-roles"
+
+	minPasswordLength = 6
+	maxPasswordLength = 72 // bcrypt limit
+	maxUserNameLength = 32
+)
+
+// session is the logged in user stored in the signed cookie, the roles are not
+// stored but resolved from demo server so that revoking takes effect shortly
+type session struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Roles  []string `json:"roles,omitempty"`
+	Expire int64    `json:"exp"`
+}
+
+// sessions signs and verifies session cookies with HMAC-SHA256
+type sessions struct {
+	client pb.DemoBackendClient
+	secret []byte
+	limit  *rateLimiter // login and register attempts
+
+	mu    sync.Mutex
+	roles map[string]userRoles // user id -> resolved roles
+}
+
+type userRoles struct {
+	roles  []string
+	expire time.Time
+}
+
+// newSessions creates session handler, a random secret is used if empty which
+// invalidates all sessions on restart
+func newSessions(client pb.DemoBackendClient, secret string) *sessions {
+	s := &sessions{client: client, secret: []byte(secret), roles: make(map[string]userRoles)}
+	if secret == "" {
+		s.secret = make([]byte, 32)
+		rand.Read(s.secret)
+	}
+	return s
+}
+
+func (s *sessions) Register(r *gin.RouterGroup) {
+	r.POST("/register", s.limit.Middleware, s.apiRegister)
+	r.POST("/login", s.limit.Middleware, s.apiLogin)
+	r.POST("/logout", s.apiLogout)
+	r.GET("/me", s.apiMe)
+}
+
+// Middleware loads the session cookie into gin context for valid sessions
+func (s *sessions) Middleware(c *gin.Context) {
+	if v, err := c.Cookie(sessionCookie); err == nil {
+		if u, err := s.decode(v); err == nil && s.resolveRoles(c, u) {
+			c.Set(ctxUser, u)
+		}
+	}
+	c.Next()
+}
+
+// resolveRoles fills the current roles of the user, cached for userRolesTTL. It
+// returns false if the user no longer exists.
+func (s *sessions) resolveRoles(c *gin.Context, u *session) bool {
+	now := time.Now()
+	s.mu.Lock()
+	r, ok := s.roles[u.ID]
+	s.mu.Unlock()
+	if ok && now.Before(r.expire) {
+		u.Roles = r.roles
+		return true
+	}
+
+	pu, err := s.client.GetUser(c, pb.GetUserRequest_builder{Id: &u.ID}.Build())
+	switch {
+	case status.Code(err) == codes.NotFound:
+		s.mu.Lock()
+		delete(s.roles, u.ID)
+		s.mu.Unlock()
+		return false
+	case err != nil:
+		// keep the user logged in without roles until demo server is reachable
+		u.Roles = nil
+		return true
+	}
+	u.Roles = pu.GetRoles()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, r := range s.roles {
+		if now.After(r.expire) {
+			delete(s.roles, id)
+		}
+	}
+	s.roles[u.ID] = userRoles{roles: u.Roles, expire: now.Add(userRolesTTL)}
+	return true
+}
+
+type credential struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func (s *sessions) apiRegister(c *gin.Context) {
+	var req credential
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	if err := validateCredential(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	u, err := s.client.CreateUser(c, pb.User_builder{
+		Name:         &req.Name,
+		PasswordHash: hash,
+	}.Build())
+	if status.Code(err) == codes.AlreadyExists {
+		c.AbortWithStatusJSON(http.StatusConflict, "User name already taken")
+		return
+	}
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	s.login(c, u)
+}
+
+func (s *sessions) apiLogin(c *gin.Context) {
+	var req credential
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	u, err := s.client.VerifyUser(c, pb.VerifyUserRequest_builder{
+		Name:     &req.Name,
+		Password: &req.Password,
+	}.Build())
+	if status.Code(err) == codes.NotFound {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, "Invalid user name or password")
+		return
+	}
+	if err != nil {
+		c.AbortWithError(grpcHTTPStatus(err), err)
+		return
+	}
+	s.login(c, u)
+}
+
+func (s *sessions) apiLogout(c *gin.Context) {
+	s.setCookie(c, "", -1)
+	c.Status(http.StatusNoContent)
+}
+
+func (s *sessions) apiMe(c *gin.Context) {
+	u, ok := currentUser(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, "Not logged in")
+		return
+	}
+	c.JSON(http.StatusOK, u)
+}
+
+func (s *sessions) login(c *gin.Context, u *pb.User) {
+	ss := &session{
+		ID:     u.GetId(),
+		Name:   u.GetName(),
+		Roles:  u.GetRoles(),
+		Expire: time.Now().Add(sessionMaxAge).Unix(),
+	}
+	v, err := s.encode(ss)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	s.setCookie(c, v, int(sessionMaxAge/time.Second))
+	c.JSON(http.StatusOK, ss)
+}
+
+func (s *sessions) setCookie(c *gin.Context, v string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, v, maxAge, "/", "", c.Request.TLS != nil, true)
+}
+
+// encode returns base64(json).base64(hmac) without the roles
+func (s *sessions) encode(ss *session) (string, error) {
+	b, err := json.Marshal(session{ID: ss.ID, Name: ss.Name, Expire: ss.Expire})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+func (s *sessions) decode(v string) (*session, error) {
+	payload, sig, ok := strings.Cut(v, ".")
+	if !ok {
+		return nil, errors.New("invalid session")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.sign(payload)) {
+		return nil, errors.New("invalid session signature")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+	ss := new(session)
+	if err := json.Unmarshal(b, ss); err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() > ss.Expire {
+		return nil, errors.New("session expired")
+	}
+	return ss, nil
+}
+
+func (s *sessions) sign(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
+
+func validateCredential(req *credential) error {
+	if req.Name == "" || len(req.Name) > maxUserNameLength {
+		return errors.New("User name must be 1 to 32 characters")
+	}
+	for _, r := range req.Name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return errors.New("User name may only contain letters, digits, '_' and '-'")
+		}
+	}
+	if len(req.Password) < minPasswordLength || len(req.Password) > maxPasswordLength {
+		return errors.New("Password must be 6 to 72 characters")
+	}
+	return nil
+}
+
+// currentUser returns the logged in user from gin context
+func currentUser(c *gin.Context) (*session, bool) {
+	u, ok := c.Get(ctxUser)
+	if !ok {
+		return nil, false
+	}
+	return u.(*session), true
+}
+
+// withUser adds the logged in user and client IP into outgoing metadata, gin.Context
+// exposes its keys through Value so the handlers could pass it directly as context
+func withUser(ctx context.Context) context.Context {
+	if ip, ok := ctx.Value(ctxClientIP).(string); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, mdClientIP, ip)
+	}
+	u, ok := ctx.Value(ctxUser).(*session)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		mdUserID, u.ID,
+		mdUserName, u.Name,
+		mdUserRoles, strings.Join(u.Roles, ","))
+}
+
+func userUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withUser(ctx), method, req, reply, cc, opts...)
+}
+
+func userStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withUser(ctx), desc, cc, method, opts...)
+}
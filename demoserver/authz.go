@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"github.com/goccy/go-yaml"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// roles of the services calling demo server, admin is allowed to call every RPC
+const (
+	roleGateway = "gateway"
+	roleJudger  = "judger"
+)
+
+// rpcRoles is the roles allowed to call each RPC, RPCs not listed are admin only
+var rpcRoles = map[string][]string{
+	pb.DemoBackend_Submission_FullMethodName:          {roleGateway},
+	pb.DemoBackend_GetSubmission_FullMethodName:       {roleGateway},
+	pb.DemoBackend_Submit_FullMethodName:              {roleGateway},
+	pb.DemoBackend_Updates_FullMethodName:             {roleGateway},
+	pb.DemoBackend_Run_FullMethodName:                 {roleGateway},
+	pb.DemoBackend_Shell_FullMethodName:               {roleGateway},
+	pb.DemoBackend_CreateProblem_FullMethodName:       {roleGateway},
+	pb.DemoBackend_GetProblem_FullMethodName:          {roleGateway},
+	pb.DemoBackend_ListProblems_FullMethodName:        {roleGateway},
+	pb.DemoBackend_UpdateProblem_FullMethodName:       {roleGateway},
+	pb.DemoBackend_ImportProblem_FullMethodName:       {roleGateway},
+	pb.DemoBackend_BuildProblem_FullMethodName:        {roleGateway},
+	pb.DemoBackend_CreateUser_FullMethodName:          {roleGateway},
+	pb.DemoBackend_GetUser_FullMethodName:             {roleGateway},
+	pb.DemoBackend_VerifyUser_FullMethodName:          {roleGateway},
+	pb.DemoBackend_ListShellSessions_FullMethodName:   {roleGateway},
+	pb.DemoBackend_GetShellSession_FullMethodName:     {roleGateway},
+	pb.DemoBackend_UploadShellFile_FullMethodName:     {roleGateway},
+	pb.DemoBackend_ListShellFiles_FullMethodName:      {roleGateway},
+	pb.DemoBackend_DeleteShellFile_FullMethodName:     {roleGateway},
+	pb.DemoBackend_GetShellArchive_FullMethodName:     {roleGateway},
+	pb.DemoBackend_GetShellWorkspace_FullMethodName:   {roleGateway},
+	pb.DemoBackend_ResetShellWorkspace_FullMethodName: {roleGateway},
+	pb.DemoBackend_ListShellProfiles_FullMethodName:   {roleGateway},
+	pb.DemoBackend_ListLiveShells_FullMethodName:      {roleGateway},
+	pb.DemoBackend_KillShell_FullMethodName:           {roleGateway},
+	pb.DemoBackend_Judge_FullMethodName:               {roleJudger},
+	pb.DemoBackend_FetchBlob_FullMethodName:           {roleJudger},
+}
+
+// principal is the authenticated service identified by its token
+type principal struct {
+	Name  string   `yaml:"name"`
+	Token string   `yaml:"token"`
+	Roles []string `yaml:"roles"`
+}
+
+// tokenConfig is the TOKEN_FILE content
+type tokenConfig struct {
+	Tokens []principal `yaml:"tokens"`
+}
+
+type principalKey struct{}
+
+// loadTokens reads the token file and adds the legacy shared token. The shared
+// token is used by both apigateway and judgers, so it gets both roles but not
+// admin.
+func loadTokens(path, token string) (map[string]*principal, error) {
+	rt := make(map[string]*principal)
+	if token != "" {
+		rt[token] = &principal{Name: "token", Token: token, Roles: []string{roleGateway, roleJudger}}
+	}
+	if path == "" {
+		return rt, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c tokenConfig
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, p := range c.Tokens {
+		if p.Token == "" {
+			return nil, fmt.Errorf("%s: empty token for %q", path, p.Name)
+		}
+		if _, ok := rt[p.Token]; ok {
+			return nil, fmt.Errorf("%s: duplicated token for %q", path, p.Name)
+		}
+		rt[p.Token] = &c.Tokens[i]
+	}
+	return rt, nil
+}
+
+// grpcTokenAuth identifies the caller by its bearer token
+func grpcTokenAuth(tokens map[string]*principal) func(context.Context) (context.Context, error) {
+	return func(ctx context.Context) (context.Context, error) {
+		reqToken, err := grpc_auth.AuthFromMD(ctx, "bearer")
+		if err != nil {
+			return nil, err
+		}
+		p, ok := tokens[reqToken]
+		if !ok {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token")
+		}
+		return context.WithValue(ctx, principalKey{}, p), nil
+	}
+}
+
+// authorize checks the authenticated principal against rpcRoles
+func authorize(ctx context.Context, method string) error {
+	p, ok := ctx.Value(principalKey{}).(*principal)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "unauthenticated call to %s", method)
+	}
+	if slices.Contains(p.Roles, roleAdmin) {
+		return nil
+	}
+	for _, r := range rpcRoles[method] {
+		if slices.Contains(p.Roles, r) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "%q with roles %v is not allowed to call %s, requires one of %v",
+		p.Name, p.Roles, method, append(slices.Clone(rpcRoles[method]), roleAdmin))
+}
+
+// isAdmin reports whether the end user forwarded by apigateway, or the calling
+// service if no user is forwarded, has the admin role
+func isAdmin(ctx context.Context) bool {
+	if u := userFromContext(ctx); u.ID != "" || u.IP != "" {
+		return slices.Contains(u.Roles, roleAdmin)
+	}
+	p, ok := ctx.Value(principalKey{}).(*principal)
+	return ok && slices.Contains(p.Roles, roleAdmin)
+}
+
+func authzUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func authzStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	blobBucket    = "blob"
+	blobChunkSize = 256 << 10 // 256k per FetchBlob message
+)
+
+// blobStore stores test data and shell archives once in GridFS, keyed by the
+// sha256 of its content
+type blobStore struct {
+	bucket *mongo.GridFSBucket
+}
+
+func newBlobStore(d *db) *blobStore {
+	return &blobStore{
+		bucket: d.database.GridFSBucket(options.GridFSBucket().SetName(blobBucket)),
+	}
+}
+
+func blobHash(content []byte) string {
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:])
+}
+
+// Put stores the content if not exists and returns its hash
+func (b *blobStore) Put(ctx context.Context, content []byte) (string, error) {
+	hash := blobHash(content)
+	n, err := b.bucket.GetFilesCollection().CountDocuments(ctx, bson.D{{Key: "_id", Value: hash}})
+	if err != nil {
+		return "", err
+	}
+	if n > 0 {
+		return hash, nil
+	}
+	err = b.bucket.UploadFromStreamWithID(ctx, hash, hash, bytes.NewReader(content))
+	if mongo.IsDuplicateKeyError(err) {
+		// uploaded concurrently
+		return hash, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// Open opens the blob for read
+func (b *blobStore) Open(ctx context.Context, hash string) (*mongo.GridFSDownloadStream, error) {
+	return b.bucket.OpenDownloadStream(ctx, hash)
+}
+
+// Get reads the whole blob
+func (b *blobStore) Get(ctx context.Context, hash string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if _, err := b.bucket.DownloadToStream(ctx, hash, buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Delete removes the blob, the callers check it is no longer referenced
+func (b *blobStore) Delete(ctx context.Context, hash string) error {
+	err := b.bucket.Delete(ctx, hash)
+	if errors.Is(err, mongo.ErrFileNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *demoServer) FetchBlob(req *pb.FetchBlobRequest, fs pb.DemoBackend_FetchBlobServer) error {
+	return s.sendBlob(fs.Context(), req.GetHash(), fs.Send)
+}
+
+// sendBlob streams the blob in chunks, the first chunk carries the size
+func (s *demoServer) sendBlob(ctx context.Context, hash string, send func(*pb.BlobChunk) error) error {
+	ds, err := s.blob.Open(ctx, hash)
+	if errors.Is(err, mongo.ErrFileNotFound) {
+		return status.Errorf(codes.NotFound, "blob %q not found", hash)
+	}
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+
+	size := uint64(ds.GetFile().Length)
+	buf := make([]byte, blobChunkSize)
+	first := true
+	for {
+		n, err := io.ReadFull(ds, buf)
+		if n > 0 || first {
+			chunk := pb.BlobChunk_builder{Content: buf[:n]}.Build()
+			if first {
+				chunk.SetSize(size)
+				first = false
+			}
+			if err := send(chunk); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
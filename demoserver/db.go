@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"time"
@@ -16,13 +17,18 @@ import (
 type Model struct {
 	ID *bson.ObjectID `json:"id" bson:"_id,omitempty"`
 
-	Lang      Language   `json:"language" bson:"language,omitempty"`
-	Source    string     `json:"source,omitempty" bson:"source,omitempty"`
-	Date      *time.Time `json:"date,omitempty" bson:"date,omitempty"`
-	Status    string     `json:"status,omitempty" bson:"status,omitempty"`
-	TotalTime uint64     `json:"totalTime,omitempty" bson:"totalTime"`
-	MaxMemory uint64     `json:"maxMemory,omitempty" bson:"maxMemory"`
-	Results   []Result   `json:"results,omitempty" bson:"results"`
+	Lang      Language     `json:"language" bson:"language,omitempty"`
+	Source    string       `json:"source,omitempty" bson:"source,omitempty"`
+	Date      *time.Time   `json:"date,omitempty" bson:"date,omitempty"`
+	Status    string       `json:"status,omitempty" bson:"status,omitempty"`
+	TotalTime uint64       `json:"totalTime,omitempty" bson:"totalTime"`
+	MaxMemory uint64       `json:"maxMemory,omitempty" bson:"maxMemory"`
+	Results   []Result     `json:"results,omitempty" bson:"results"`
+	Files     []SourceFile `json:"files,omitempty" bson:"files,omitempty"`
+	ProblemID string       `json:"problemId,omitempty" bson:"problemId,omitempty"`
+	UserID    string       `json:"userId,omitempty" bson:"userId,omitempty"`
+	UserName  string       `json:"userName,omitempty" bson:"userName,omitempty"`
+	Type      string       `json:"type,omitempty" bson:"type,omitempty"` // of the last update
 }
 
 // Language defines the way to compile / run
@@ -34,6 +40,12 @@ type Language struct {
 	RunCmd         string `json:"runCmd" bson:"runCmd"`
 }
 
+// SourceFile is an additional file submitted along with the source
+type SourceFile struct {
+	Name    string `json:"name" bson:"name"`
+	Content []byte `json:"content" bson:"content"`
+}
+
 // Result is the judger updates
 type Result struct {
 	Time   uint64 `json:"time,omitempty" bson:"time,omitempty"`
@@ -55,18 +67,105 @@ type JudgerUpdate struct {
 	Results  []Result   `json:"results,omitempty"`
 }
 
-// ShellStore stores shell interaction
-type ShellStore struct {
+// ShellSession is the metadata of a recorded shell, the events are stored in
+// ShellEvents chunks
+type ShellSession struct {
+	ID *bson.ObjectID `json:"id" bson:"_id,omitempty"`
+
+	UserID     string     `json:"userId,omitempty" bson:"userId,omitempty"`
+	UserName   string     `json:"userName,omitempty" bson:"userName,omitempty"`
+	RemoteAddr string     `json:"remoteAddr,omitempty" bson:"remoteAddr,omitempty"`
+	Start      *time.Time `json:"start" bson:"start"`
+	End        *time.Time `json:"end,omitempty" bson:"end,omitempty"`
+	Width      uint32     `json:"width" bson:"width"`
+	Height     uint32     `json:"height" bson:"height"`
+	Status     string     `json:"status,omitempty" bson:"status,omitempty"`
+	ExitStatus int32      `json:"exitStatus" bson:"exitStatus"`
+	Time       uint64     `json:"time" bson:"time"`     // ms
+	Memory     uint64     `json:"memory" bson:"memory"` // kb
+	Events     int        `json:"events" bson:"events"`
+	Chunks     int        `json:"chunks" bson:"chunks"`
+	Truncated  bool       `json:"truncated,omitempty" bson:"truncated,omitempty"`
+	Archive    string     `json:"archive,omitempty" bson:"archive,omitempty"` // blob hash of the working directory
+	Profile    string     `json:"profile,omitempty" bson:"profile,omitempty"`
+	Command    []string   `json:"command,omitempty" bson:"command,omitempty"` // profile args, without the workspace wrapper
+	Term       string     `json:"term,omitempty" bson:"term,omitempty"`
+}
+
+// ShellEvents is a chunk of the ordered shell events
+type ShellEvents struct {
 	ID *bson.ObjectID `json:"id" bson:"_id,omitempty"`
 
-	Stdin  string `bson:"stdin"`
-	Stdout string `bson:"stdout"`
+	SessionID bson.ObjectID `json:"sessionId" bson:"sessionId"`
+	Seq       int           `json:"seq" bson:"seq"`
+	Events    []ShellEvent  `json:"events" bson:"events"`
+}
+
+// ShellEvent is an asciicast v2 event: "o" for output, "i" for input and "r"
+// for resize as `<cols>x<rows>`
+type ShellEvent struct {
+	Time float64 `json:"t" bson:"t"` // seconds since start
+	Type string  `json:"e" bson:"e"`
+	Data string  `json:"d" bson:"d"`
+}
+
+// ShellWorkspace is the working directory of the user's last shell, restored in
+// the next shell
+type ShellWorkspace struct {
+	UserID  string     `json:"userId" bson:"_id"`
+	Archive string     `json:"archive" bson:"archive"` // blob hash of the tar archive
+	Size    int        `json:"size" bson:"size"`
+	Updated *time.Time `json:"updated" bson:"updated"`
+}
+
+// Problem stores the statement, limits, checker and test data
+type Problem struct {
+	ID *bson.ObjectID `json:"id" bson:"_id,omitempty"`
+
+	Title       string        `json:"title" bson:"title"`
+	Statement   string        `json:"statement" bson:"statement"`
+	TimeLimit   uint64        `json:"timeLimit" bson:"timeLimit"`     // ms
+	MemoryLimit uint64        `json:"memoryLimit" bson:"memoryLimit"` // kb
+	Checker     *Checker      `json:"checker,omitempty" bson:"checker,omitempty"`
+	TestCases   []InputAnswer `json:"testCases,omitempty" bson:"testCases,omitempty"`
+	Date        *time.Time    `json:"date,omitempty" bson:"date,omitempty"`
+	BuildStatus string        `json:"buildStatus,omitempty" bson:"buildStatus,omitempty"`
+	BuildLog    string        `json:"buildLog,omitempty" bson:"buildLog,omitempty"`
+}
+
+// Checker compares the output with the answer, empty source for the default text compare
+type Checker struct {
+	Lang   Language     `json:"language" bson:"language"`
+	Source string       `json:"source" bson:"source"`
+	Files  []SourceFile `json:"files,omitempty" bson:"files,omitempty"`
+}
+
+// InputAnswer is a single test case, the content is kept in blob store and referenced by hash
+type InputAnswer struct {
+	Input      string `json:"input,omitempty" bson:"input,omitempty"`
+	Answer     string `json:"answer,omitempty" bson:"answer,omitempty"`
+	InputHash  string `json:"inputHash,omitempty" bson:"inputHash,omitempty"`
+	AnswerHash string `json:"answerHash,omitempty" bson:"answerHash,omitempty"`
 }
 
 // ClientSubmit is the job submit model uploaded by client ws
 type ClientSubmit struct {
-	Lang   Language `json:"language"`
-	Source string   `json:"source"`
+	Lang      Language     `json:"language"`
+	Source    string       `json:"source"`
+	Files     []SourceFile `json:"files,omitempty"`
+	ProblemID string       `json:"problemId,omitempty"`
+	UserID    string       `json:"userId,omitempty"`
+	UserName  string       `json:"userName,omitempty"`
+}
+
+// User is a registered account, password is hashed by apigateway
+type User struct {
+	ID *bson.ObjectID `json:"id" bson:"_id,omitempty"`
+
+	Name         string     `json:"name" bson:"name"`
+	PasswordHash []byte     `json:"-" bson:"passwordHash"`
+	Roles        []string   `json:"roles,omitempty" bson:"roles,omitempty"`
+	Date         *time.Time `json:"date,omitempty" bson:"date,omitempty"`
 }
 
 type db struct {
@@ -76,6 +175,10 @@ type db struct {
 const (
 	colName         = "submission3"
 	colName2        = "shell1"
+	colShellEvents  = "shell1.events"
+	colWorkspace    = "shell1.workspaces"
+	colProblem      = "problems"
+	colUser         = "users"
 	defaultURI      = "mongodb://localhost:27017/test"
 	defaultDatabase = "test1"
 )
@@ -101,18 +204,38 @@ func getDB() *db {
 		log.Fatalln(err)
 		return nil
 	}
-	return &db{
+	d := &db{
 		database: client.Database(database),
 	}
+	// user names are unique
+	_, err = d.database.Collection(colUser).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Println("create user index", err)
+	}
+	_, err = d.database.Collection(colShellEvents).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "sessionId", Value: 1}, {Key: "seq", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Println("create shell events index", err)
+	}
+	return d
 }
 
 func (d *db) Add(ctx context.Context, cs *ClientSubmit) (*Model, error) {
 	c := d.database.Collection(colName)
 	t := time.Now()
 	m := &Model{
-		Lang:   cs.Lang,
-		Source: cs.Source,
-		Date:   &t,
+		Lang:      cs.Lang,
+		Source:    cs.Source,
+		Date:      &t,
+		Files:     cs.Files,
+		ProblemID: cs.ProblemID,
+		UserID:    cs.UserID,
+		UserName:  cs.UserName,
 	}
 	i, err := c.InsertOne(ctx, m)
 	if err != nil {
@@ -128,6 +251,7 @@ func (d *db) Update(ctx context.Context, m *JudgerUpdate) (*JudgerUpdate, error)
 
 	filter := bson.D{{Key: "_id", Value: m.ID}}
 	update := bson.D{
+		{Key: "type", Value: m.Type},
 		{Key: "status", Value: m.Status},
 		{Key: "results", Value: m.Results},
 	}
@@ -142,6 +266,21 @@ func (d *db) Update(ctx context.Context, m *JudgerUpdate) (*JudgerUpdate, error)
 	return m, nil
 }
 
+// GetSubmission returns the submission by id
+func (d *db) GetSubmission(ctx context.Context, id string) (*Model, error) {
+	c := d.database.Collection(colName)
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	m := new(Model)
+	if err := c.FindOne(ctx, bson.D{{Key: "_id", Value: oid}}).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (d *db) Query(ctx context.Context, id string) ([]Model, error) {
 	c := d.database.Collection(colName)
 
@@ -178,8 +317,359 @@ func (d *db) Query(ctx context.Context, id string) ([]Model, error) {
 	return rt, nil
 }
 
-func (d *db) Store(ctx context.Context, ss *ShellStore) error {
+func (d *db) AddShellSession(ctx context.Context, ss *ShellSession) (*ShellSession, error) {
+	c := d.database.Collection(colName2)
+	i, err := c.InsertOne(ctx, ss)
+	if err != nil {
+		return nil, err
+	}
+	id := i.InsertedID.(bson.ObjectID)
+	ss.ID = &id
+	return ss, nil
+}
+
+// FinishShellSession stores the end time, size, exit status and event counts
+func (d *db) FinishShellSession(ctx context.Context, ss *ShellSession) error {
 	c := d.database.Collection(colName2)
-	_, err := c.InsertOne(ctx, ss)
+	_, err := c.UpdateOne(ctx, bson.D{{Key: "_id", Value: ss.ID}}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "end", Value: ss.End},
+		{Key: "width", Value: ss.Width},
+		{Key: "height", Value: ss.Height},
+		{Key: "status", Value: ss.Status},
+		{Key: "exitStatus", Value: ss.ExitStatus},
+		{Key: "time", Value: ss.Time},
+		{Key: "memory", Value: ss.Memory},
+		{Key: "events", Value: ss.Events},
+		{Key: "chunks", Value: ss.Chunks},
+		{Key: "truncated", Value: ss.Truncated},
+		{Key: "archive", Value: ss.Archive},
+	}}})
 	return err
 }
+
+func (d *db) AddShellEvents(ctx context.Context, se *ShellEvents) error {
+	c := d.database.Collection(colShellEvents)
+	_, err := c.InsertOne(ctx, se)
+	return err
+}
+
+// QueryShellSessions lists the latest sessions before id, of the user if not empty
+func (d *db) QueryShellSessions(ctx context.Context, id, userID string) ([]ShellSession, error) {
+	c := d.database.Collection(colName2)
+
+	findOption := options.Find()
+	findOption.SetLimit(20)
+	findOption.SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	filter := bson.D{}
+	if len(id) > 0 {
+		oid, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, bson.E{
+			Key:   "_id",
+			Value: bson.D{{Key: "$lt", Value: oid}},
+		})
+	}
+	if userID != "" {
+		filter = append(filter, bson.E{Key: "userId", Value: userID})
+	}
+
+	cursor, err := c.Find(ctx, filter, findOption)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rt := make([]ShellSession, 0, 20)
+	for cursor.Next(ctx) {
+		el := ShellSession{}
+		if err = cursor.Decode(&el); err != nil {
+			return nil, err
+		}
+		rt = append(rt, el)
+	}
+	return rt, nil
+}
+
+func (d *db) GetShellSession(ctx context.Context, id string) (*ShellSession, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	c := d.database.Collection(colName2)
+	rt := new(ShellSession)
+	if err := c.FindOne(ctx, bson.D{{Key: "_id", Value: oid}}).Decode(rt); err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// EachShellEvents calls fn with the event chunks of the session in order
+func (d *db) EachShellEvents(ctx context.Context, sessionID bson.ObjectID, fn func(*ShellEvents) error) error {
+	c := d.database.Collection(colShellEvents)
+	findOption := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}})
+	cursor, err := c.Find(ctx, bson.D{{Key: "sessionId", Value: sessionID}}, findOption)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		el := new(ShellEvents)
+		if err := cursor.Decode(el); err != nil {
+			return err
+		}
+		if err := fn(el); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// ExpireShellArchives removes the archives of the sessions ended before the time
+// and returns their blob hashes
+func (d *db) ExpireShellArchives(ctx context.Context, before time.Time) ([]string, error) {
+	c := d.database.Collection(colName2)
+	filter := bson.D{
+		{Key: "archive", Value: bson.D{{Key: "$exists", Value: true}, {Key: "$ne", Value: ""}}},
+		{Key: "end", Value: bson.D{{Key: "$lt", Value: before}}},
+	}
+	cursor, err := c.Find(ctx, filter, options.Find().SetProjection(bson.D{{Key: "archive", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var (
+		ids    []bson.ObjectID
+		hashes []string
+	)
+	for cursor.Next(ctx) {
+		el := ShellSession{}
+		if err := cursor.Decode(&el); err != nil {
+			return nil, err
+		}
+		ids = append(ids, *el.ID)
+		hashes = append(hashes, el.Archive)
+	}
+	if err := cursor.Err(); err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	_, err = c.UpdateMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}},
+		bson.D{{Key: "$unset", Value: bson.D{{Key: "archive", Value: ""}}}})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// BlobReferenced checks if the blob is the test data of a problem, the archive of
+// a shell session or a workspace
+func (d *db) BlobReferenced(ctx context.Context, hash string) (bool, error) {
+	refs := []struct {
+		col    string
+		filter bson.D
+	}{
+		{colProblem, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "testCases.inputHash", Value: hash}},
+			bson.D{{Key: "testCases.answerHash", Value: hash}},
+		}}}},
+		{colName2, bson.D{{Key: "archive", Value: hash}}},
+		{colWorkspace, bson.D{{Key: "archive", Value: hash}}},
+	}
+	for _, r := range refs {
+		n, err := d.database.Collection(r.col).CountDocuments(ctx, r.filter, options.Count().SetLimit(1))
+		if err != nil || n > 0 {
+			return n > 0, err
+		}
+	}
+	return false, nil
+}
+
+// GetShellWorkspace returns the workspace of the user, or nil if not exists
+func (d *db) GetShellWorkspace(ctx context.Context, userID string) (*ShellWorkspace, error) {
+	c := d.database.Collection(colWorkspace)
+	rt := new(ShellWorkspace)
+	err := c.FindOne(ctx, bson.D{{Key: "_id", Value: userID}}).Decode(rt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// SetShellWorkspace replaces the workspace of the user and returns the previous
+// one, or nil if not exists
+func (d *db) SetShellWorkspace(ctx context.Context, ws *ShellWorkspace) (*ShellWorkspace, error) {
+	c := d.database.Collection(colWorkspace)
+	rt := new(ShellWorkspace)
+	err := c.FindOneAndReplace(ctx, bson.D{{Key: "_id", Value: ws.UserID}}, ws, options.FindOneAndReplace().SetUpsert(true)).Decode(rt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// DeleteShellWorkspace deletes the workspace of the user and returns it, or nil
+// if not exists
+func (d *db) DeleteShellWorkspace(ctx context.Context, userID string) (*ShellWorkspace, error) {
+	c := d.database.Collection(colWorkspace)
+	rt := new(ShellWorkspace)
+	err := c.FindOneAndDelete(ctx, bson.D{{Key: "_id", Value: userID}}).Decode(rt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+func (d *db) AddProblem(ctx context.Context, p *Problem) (*Problem, error) {
+	c := d.database.Collection(colProblem)
+	t := time.Now()
+	p.ID = nil
+	p.Date = &t
+	i, err := c.InsertOne(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	id := i.InsertedID.(bson.ObjectID)
+	p.ID = &id
+	return p, nil
+}
+
+func (d *db) GetProblem(ctx context.Context, id string) (*Problem, error) {
+	c := d.database.Collection(colProblem)
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	p := new(Problem)
+	if err := c.FindOne(ctx, bson.D{{Key: "_id", Value: oid}}).Decode(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// QueryProblems lists problems without test data
+func (d *db) QueryProblems(ctx context.Context, id string) ([]Problem, error) {
+	c := d.database.Collection(colProblem)
+
+	findOption := options.Find()
+	findOption.SetLimit(10)
+	findOption.SetSort(bson.D{{Key: "_id", Value: -1}})
+	findOption.SetProjection(bson.D{{Key: "testCases", Value: 0}})
+
+	filter := bson.D{}
+	if len(id) > 0 {
+		oid, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, bson.E{
+			Key:   "_id",
+			Value: bson.D{{Key: "$lt", Value: oid}},
+		})
+	}
+
+	cursor, err := c.Find(ctx, filter, findOption)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rt := make([]Problem, 0, 10)
+	for cursor.Next(ctx) {
+		el := Problem{}
+		if err = cursor.Decode(&el); err != nil {
+			return nil, err
+		}
+		rt = append(rt, el)
+	}
+	return rt, nil
+}
+
+func (d *db) UpdateProblem(ctx context.Context, p *Problem) (*Problem, error) {
+	c := d.database.Collection(colProblem)
+
+	filter := bson.D{{Key: "_id", Value: p.ID}}
+	update := bson.D{
+		{Key: "title", Value: p.Title},
+		{Key: "statement", Value: p.Statement},
+		{Key: "timeLimit", Value: p.TimeLimit},
+		{Key: "memoryLimit", Value: p.MemoryLimit},
+		{Key: "checker", Value: p.Checker},
+		{Key: "testCases", Value: p.TestCases},
+	}
+	updateCmd := bson.D{
+		{Key: "$set", Value: update},
+	}
+
+	after := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	rt := new(Problem)
+	if err := c.FindOneAndUpdate(ctx, filter, updateCmd, after).Decode(rt); err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// SetProblemBuild records the test data build status, test cases are replaced only when not nil
+func (d *db) SetProblemBuild(ctx context.Context, id string, status, log string, testCases []InputAnswer) error {
+	c := d.database.Collection(colProblem)
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.D{
+		{Key: "buildStatus", Value: status},
+		{Key: "buildLog", Value: log},
+	}
+	if testCases != nil {
+		update = append(update, bson.E{Key: "testCases", Value: testCases})
+	}
+	_, err = c.UpdateOne(ctx, bson.D{{Key: "_id", Value: oid}}, bson.D{{Key: "$set", Value: update}})
+	return err
+}
+
+func (d *db) AddUser(ctx context.Context, u *User) (*User, error) {
+	c := d.database.Collection(colUser)
+	t := time.Now()
+	u.ID = nil
+	u.Date = &t
+	i, err := c.InsertOne(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	id := i.InsertedID.(bson.ObjectID)
+	u.ID = &id
+	return u, nil
+}
+
+// GetUser finds the user by id, or by name if id is empty
+func (d *db) GetUser(ctx context.Context, id, name string) (*User, error) {
+	c := d.database.Collection(colUser)
+
+	filter := bson.D{{Key: "name", Value: name}}
+	if id != "" {
+		oid, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.D{{Key: "_id", Value: oid}}
+	}
+	u := new(User)
+	if err := c.FindOne(ctx, filter).Decode(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
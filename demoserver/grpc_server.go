@@ -1,49 +1,112 @@
 package main
 
 import (
-	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/criyle/go-judge-demo/internal/judgeupdate"
 	"github.com/criyle/go-judge-demo/pb"
 	execpb "github.com/criyle/go-judge/pb"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type demoServer struct {
 	pb.UnimplementedDemoBackendServer
 	db     *db
+	blob   *blobStore
 	logger *zap.Logger
 	client execpb.ExecutorClient
+	admins []string // user names granted admin role
 
-	submit chan *pb.JudgeClientRequest
-	update chan *pb.JudgeClientResponse
+	submitLimit *rateLimiter
+	shells      *concurrencyLimiter
+	sessions    *shellSessions // running shells
+	shellGrace  time.Duration
+	shellIdle   time.Duration
+	files       *shellFiles // uploaded for the new shells
+	profiles    *shellProfiles
+	runTimeout  time.Duration
+
+	queue     *judgeQueue
+	meta      *submissionMeta
+	replay    *replayBuffer
+	update    chan *pb.JudgeClientResponse
+	broadcast chan *pb.JudgeUpdate // updates not stored, e.g. queue positions
 
 	register   chan *observer
 	unregister chan *observer
 	observers  map[*observer]bool
 }
 
-func newDemoServer(db *db, client execpb.ExecutorClient, logger *zap.Logger) *demoServer {
+// demoConfig is the demo server configuration from env
+type demoConfig struct {
+	Admins            []string
+	SubmitRate        rate.Limit
+	SubmitBurst       int
+	ShellMaxPerCaller int
+	ShellMax          int
+	QueueMax          int
+	UpdateReplay      int
+	RunTimeout        time.Duration
+	ShellGrace        time.Duration
+	ShellIdle         time.Duration
+	ShellUploadMax    int
+	ShellArchiveMax   int
+	ShellWorkspaceMax int
+	ShellArchiveKeep  time.Duration
+	ShellProfiles     *shellProfiles
+}
+
+func newDemoServer(db *db, client execpb.ExecutorClient, logger *zap.Logger, conf demoConfig) *demoServer {
 	ds := &demoServer{
-		db:         db,
-		logger:     logger,
-		client:     client,
-		submit:     make(chan *pb.JudgeClientRequest, 64),
-		update:     make(chan *pb.JudgeClientResponse, 64),
-		register:   make(chan *observer, 64),
-		unregister: make(chan *observer, 64),
-		observers:  make(map[*observer]bool),
+		db:          db,
+		admins:      conf.Admins,
+		submitLimit: newRateLimiter(conf.SubmitRate, conf.SubmitBurst),
+		shells:      newConcurrencyLimiter(conf.ShellMaxPerCaller, conf.ShellMax),
+		sessions:    newShellSessions(),
+		shellGrace:  conf.ShellGrace,
+		shellIdle:   conf.ShellIdle,
+		profiles:    conf.ShellProfiles,
+		files:       newShellFiles(uint64(conf.ShellUploadMax), uint64(conf.ShellArchiveMax), conf.ShellWorkspaceMax),
+		blob:        newBlobStore(db),
+		logger:      logger,
+		client:      client,
+		queue:       newJudgeQueue(conf.QueueMax),
+		runTimeout:  conf.RunTimeout,
+		meta:        newSubmissionMeta(),
+		replay:      newReplayBuffer(conf.UpdateReplay),
+		update:      make(chan *pb.JudgeClientResponse, 64),
+		broadcast:   make(chan *pb.JudgeUpdate, 64),
+		register:    make(chan *observer, 64),
+		unregister:  make(chan *observer, 64),
+		observers:   make(map[*observer]bool),
 	}
 	go ds.updateLoop()
+	go ds.queueLoop()
+	go ds.shellArchiveLoop(conf.ShellArchiveKeep)
 	return ds
 }
 
 type observer struct {
+	filter *pb.UpdatesRequest
 	update chan *pb.JudgeUpdate
+
+	// replay receives the missed updates on register, closed without sending
+	// if they are no longer available
+	replay chan []*pb.JudgeUpdate
 }
 
 func (s *demoServer) Submission(ctx context.Context, req *pb.SubmissionRequest) (*pb.SubmissionResponse, error) {
@@ -54,70 +117,167 @@ func (s *demoServer) Submission(ctx context.Context, req *pb.SubmissionRequest)
 	}
 	sub := make([]*pb.Submission, 0, len(m))
 	for _, v := range m {
-		id := v.ID.Hex()
-		sub = append(sub, pb.Submission_builder{
-			Id:        &id,
-			Language:  convertLanguage(v.Lang),
-			Source:    &v.Source,
-			Date:      timestamppb.New(*v.Date),
-			Status:    &v.Status,
-			TotalTime: &v.TotalTime,
-			MaxMemory: &v.MaxMemory,
-			Results:   convertResults(v.Results),
-		}.Build())
+		sub = append(sub, convertSubmission(&v))
 	}
 	return pb.SubmissionResponse_builder{Submissions: sub}.Build(), nil
 }
 
+func (s *demoServer) GetSubmission(ctx context.Context, req *pb.GetSubmissionRequest) (*pb.Submission, error) {
+	if _, err := bson.ObjectIDFromHex(req.GetId()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid submission id %q", req.GetId())
+	}
+	m, err := s.db.GetSubmission(ctx, req.GetId())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "submission %q not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertSubmission(m), nil
+}
+
+func convertSubmission(v *Model) *pb.Submission {
+	id := v.ID.Hex()
+	return pb.Submission_builder{
+		Id:        &id,
+		Language:  convertLanguage(v.Lang),
+		Source:    &v.Source,
+		Date:      timestamppb.New(*v.Date),
+		Status:    &v.Status,
+		TotalTime: &v.TotalTime,
+		MaxMemory: &v.MaxMemory,
+		Results:   convertResults(v.Results),
+		Files:     convertSourceFiles(v.Files),
+		ProblemId: &v.ProblemID,
+		UserId:    &v.UserID,
+		UserName:  &v.UserName,
+		Type:      &v.Type,
+	}.Build()
+}
+
 func (s *demoServer) Submit(ctx context.Context, req *pb.SubmitRequest) (*pb.SubmitResponse, error) {
+	return s.submit(ctx, req, nil)
+}
+
+// submit queues the submission, the updates are also sent to the waiter if not nil
+func (s *demoServer) submit(ctx context.Context, req *pb.SubmitRequest, waiter chan *pb.JudgeUpdate) (*pb.SubmitResponse, error) {
+	if err := checkSourceFiles(req); err != nil {
+		return nil, err
+	}
+	if err := s.submitLimit.Allow(ctx, "submit"); err != nil {
+		return nil, err
+	}
+	class := classInteractive
+	jreq := pb.JudgeClientRequest_builder{
+		Language:    req.GetLanguage(),
+		InputAnswer: req.GetInputAnswer(),
+		Files:       req.GetFiles(),
+	}.Build()
+	// resolve test data from problem
+	if req.GetProblemId() != "" {
+		p, err := s.getProblem(ctx, req.GetProblemId())
+		if err != nil {
+			return nil, err
+		}
+		jreq.SetInputAnswer(convertInputAnswers(p.TestCases))
+		jreq.SetTimeLimit(p.TimeLimit)
+		jreq.SetMemoryLimit(p.MemoryLimit)
+		jreq.SetChecker(convertChecker(p.Checker))
+		class = classContest
+	}
+	// users could only lower the priority of their own submissions
+	c := convertPriorityPB(req.GetPriority(), class)
+	if c < class && !isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "priority %v requires admin role", c)
+	}
+	class = c
+
+	slot, err := s.queue.Reserve()
+	if err != nil {
+		return nil, err
+	}
+	defer slot.Cancel()
+
+	user := userFromContext(ctx)
 	m, err := s.db.Add(ctx, &ClientSubmit{
-		Lang:   convertLanguagePB(req.GetLanguage()),
-		Source: req.GetSource(),
+		Lang:      convertLanguagePB(req.GetLanguage()),
+		Source:    req.GetSource(),
+		Files:     convertSourceFilesPB(req.GetFiles()),
+		ProblemID: req.GetProblemId(),
+		UserID:    user.ID,
+		UserName:  user.Name,
 	})
 	if err != nil {
 		return nil, err
 	}
 	id := m.ID.Hex()
 	source := req.GetSource()
-	s.submit <- pb.JudgeClientRequest_builder{
-		Id:          &id,
-		Language:    req.GetLanguage(),
-		Source:      &source,
-		InputAnswer: req.GetInputAnswer(),
-	}.Build()
+	jreq.SetId(id)
+	jreq.SetSource(source)
+	s.meta.Store(id, updateMeta{userID: user.ID, language: req.GetLanguage(), waiter: waiter})
 	s.update <- pb.JudgeClientResponse_builder{
 		Id:       &id,
 		Language: req.GetLanguage(),
 		Date:     timestamppb.New(*m.Date),
 		Source:   &source,
 	}.Build()
-	s.logger.Debug("submit", zap.Any("model", m))
+	pos := uint32(slot.Push(jreq, class, callerKey(ctx)))
+	s.logger.Debug("submit", zap.Any("model", m), zap.Stringer("priority", class), zap.Uint32("queuePosition", pos))
 	return pb.SubmitResponse_builder{
-		Id: &id,
+		Id:            &id,
+		QueuePosition: &pos,
 	}.Build(), nil
 }
 
+// checkSourceFiles checks the source file names are distinct relative paths
+// inside the working directory, they are copied into the sandbox as is
+func checkSourceFiles(req *pb.SubmitRequest) error {
+	names := []string{req.GetLanguage().GetSourceFileName()}
+	for _, f := range req.GetFiles() {
+		names = append(names, f.GetName())
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || name == "." || path.Clean(name) != name || path.IsAbs(name) || name == ".." ||
+			strings.HasPrefix(name, "../") || strings.ContainsAny(name, "\\\x00") {
+			return status.Errorf(codes.InvalidArgument, "invalid source file name %q", name)
+		}
+		if seen[name] {
+			return status.Errorf(codes.InvalidArgument, "duplicated source file name %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
 func (s *demoServer) Judge(js pb.DemoBackend_JudgeServer) error {
 	for {
 		// Send request to client
-		req, ok := <-s.submit
-		s.logger.Info("judge request", zap.Any("request", req))
-		if !ok {
-			break
+		req, err := s.queue.Pop(js.Context())
+		if err != nil {
+			return nil
 		}
-		err := js.Send(req)
+		s.logger.Info("judge request", zap.Any("request", req))
+		err = js.Send(req)
 		if err != nil {
 			// If encouters error, do not consume this
-			s.submit <- req
+			s.queue.Requeue(req)
 			return err
 		}
+		if req.HasBuildProblem() {
+			if err := s.judgeBuild(js, req); err != nil {
+				s.queue.Requeue(req)
+				return err
+			}
+			continue
+		}
 		// Recv updates from client
 		for {
 			resp, err := js.Recv()
 			s.logger.Info("judge response", zap.Any("request", req), zap.Error(err))
 			if err != nil {
 				// If encouters error, do not consume this
-				s.submit <- req
+				s.queue.Requeue(req)
 				return err
 			}
 			s.update <- resp
@@ -126,13 +286,31 @@ func (s *demoServer) Judge(js pb.DemoBackend_JudgeServer) error {
 			}
 		}
 	}
-	return nil
 }
 
-func (s *demoServer) Updates(_ *emptypb.Empty, us pb.DemoBackend_UpdatesServer) error {
-	ob := &observer{update: make(chan *pb.JudgeUpdate, 64)}
+func (s *demoServer) Updates(req *pb.UpdatesRequest, us pb.DemoBackend_UpdatesServer) error {
+	ob := &observer{
+		filter: req,
+		update: make(chan *pb.JudgeUpdate, 64),
+		replay: make(chan []*pb.JudgeUpdate, 1),
+	}
 	s.register <- ob
 	defer func() { s.unregister <- ob }()
+
+	missed, ok := <-ob.replay
+	if !ok {
+		return status.Errorf(codes.OutOfRange, "updates since %d are no longer available", req.GetSince())
+	}
+	// the header tells the client the observer is registered, so the state read
+	// after it is not older than the updates
+	if err := us.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for _, u := range missed {
+		if err := us.Send(u); err != nil {
+			return err
+		}
+	}
 	for {
 		select {
 		case <-us.Context().Done():
@@ -140,7 +318,7 @@ func (s *demoServer) Updates(_ *emptypb.Empty, us pb.DemoBackend_UpdatesServer)
 
 		case u, ok := <-ob.update:
 			if !ok {
-				return nil
+				return status.Errorf(codes.Aborted, "observer too slow, resume with since")
 			}
 			s.logger.Info("send updates", zap.Any("updates", u))
 			if err := us.Send(u); err != nil {
@@ -150,110 +328,18 @@ func (s *demoServer) Updates(_ *emptypb.Empty, us pb.DemoBackend_UpdatesServer)
 	}
 }
 
-func (s *demoServer) Shell(ss pb.DemoBackend_ShellServer) error {
-	ctx, cancel := context.WithCancel(ss.Context())
-	defer cancel()
-
-	sc, err := s.client.ExecStream(ctx)
-	if err != nil {
-		return err
-	}
-	err = sc.Send(execpb.StreamRequest_builder{
-		ExecRequest: execpb.Request_builder{
-			Cmd: []*execpb.Request_CmdType{execpb.Request_CmdType_builder{
-				Args: []string{"/bin/bash"},
-				Env:  []string{"PATH=/usr/local/bin:/usr/bin:/bin", "HOME=/w", "TERM=xterm-256color"},
-				Files: []*execpb.Request_File{
-					execpb.Request_File_builder{StreamIn: &emptypb.Empty{}}.Build(),
-					execpb.Request_File_builder{StreamOut: &emptypb.Empty{}}.Build(),
-					execpb.Request_File_builder{StreamOut: &emptypb.Empty{}}.Build(),
-				},
-				Tty:            true,
-				CpuTimeLimit:   uint64(30 * time.Second),
-				ClockTimeLimit: uint64(30 * time.Minute),
-				MemoryLimit:    256 << 20,
-				ProcLimit:      50,
-			}.Build()},
-		}.Build(),
-	}.Build())
-	if err != nil {
-		return err
-	}
-	input := new(bytes.Buffer)
-	output := new(bytes.Buffer)
-
-	go func() {
-		defer cancel()
-
-		for {
-			msg, err := sc.Recv()
-			s.logger.Debug("sc recv", zap.Any("message", msg))
-			if err != nil {
-				return
-			}
-			switch msg.WhichResponse() {
-			case execpb.StreamResponse_ExecOutput_case:
-				output.Write(msg.GetExecOutput().GetContent())
-				err = ss.Send(pb.ShellOutput_builder{Content: msg.GetExecOutput().GetContent()}.Build())
-				if err != nil {
-					return
-				}
-
-			case execpb.StreamResponse_ExecResponse_case:
-				err = ss.Send(pb.ShellOutput_builder{Content: []byte(msg.GetExecResponse().String())}.Build())
-				if err != nil {
-					return
-				}
-				return
-			}
-		}
-	}()
-
-	go func() {
-		defer cancel()
-
-		for {
-			msg, err := ss.Recv()
-			s.logger.Debug("ss recv", zap.Any("message", msg))
-			if err != nil {
-				return
-			}
-			switch msg.WhichRequest() {
-			case pb.ShellInput_Input_case:
-				input.Write(msg.GetInput().GetContent())
-				err = sc.Send(execpb.StreamRequest_builder{ExecInput: execpb.StreamRequest_Input_builder{
-					Content: msg.GetInput().GetContent(),
-				}.Build()}.Build())
-				if err != nil {
-					return
-				}
-
-			case pb.ShellInput_Resize_case:
-				err = sc.Send(execpb.StreamRequest_builder{ExecResize: execpb.StreamRequest_Resize_builder{
-					Rows: msg.GetResize().GetRows(),
-					Cols: msg.GetResize().GetCols(),
-					X:    msg.GetResize().GetX(),
-					Y:    msg.GetResize().GetY(),
-				}.Build()}.Build())
-				if err != nil {
-					return
-				}
-			}
-		}
-	}()
-	<-ctx.Done()
-
-	s.db.Store(context.TODO(), &ShellStore{
-		Stdin:  input.String(),
-		Stdout: output.String(),
-	})
-	return nil
-}
-
 func (s *demoServer) updateLoop() {
 	for {
 		select {
 		case o := <-s.register:
+			missed, ok := s.replay.Since(o.filter.GetSince())
+			if !ok {
+				close(o.replay)
+				continue
+			}
+			o.replay <- slices.DeleteFunc(missed, func(u *pb.JudgeUpdate) bool {
+				return !judgeupdate.Match(o.filter, u)
+			})
 			s.observers[o] = true
 		case o := <-s.unregister:
 			delete(s.observers, o)
@@ -276,19 +362,77 @@ func (s *demoServer) updateLoop() {
 				Status:  u.GetStatus(),
 				Results: convertResultsPB(u.GetResults()),
 			})
-			// broadcast
-			for o := range s.observers {
-				select {
-				case o.update <- up:
-				default:
-					// too slow
-					//close(o.update)
-				}
+			s.meta.Annotate(up)
+			s.replay.Add(up)
+			s.broadcastUpdate(up, false)
+
+		case up := <-s.broadcast:
+			s.meta.Annotate(up)
+			s.broadcastUpdate(up, true)
+		}
+	}
+}
+
+// broadcastUpdate sends the update to the matching observers. Transient updates,
+// e.g. queue positions, are not replayed, so they are dropped for slow observers
+// instead of closing them.
+func (s *demoServer) broadcastUpdate(up *pb.JudgeUpdate, transient bool) {
+	for o := range s.observers {
+		if !judgeupdate.Match(o.filter, up) {
+			continue
+		}
+		select {
+		case o.update <- up:
+		default:
+			if transient {
+				continue
 			}
+			// too slow, the observer should resume with since
+			delete(s.observers, o)
+			close(o.update)
 		}
 	}
 }
 
+// queueLoop broadcasts the queue positions of the waiting submissions when they
+// change, only the submissions whose position changed are sent. The positions are
+// also checked periodically as the waiting requests age into higher classes.
+func (s *demoServer) queueLoop() {
+	ticker := time.NewTicker(queuePositionCheck)
+	defer ticker.Stop()
+
+	last := make(map[string]uint32)
+	for {
+		select {
+		case <-s.queue.Changed():
+		case <-ticker.C:
+			if len(last) == 0 {
+				continue
+			}
+		}
+		pos := uint32(0)
+		current := make(map[string]uint32, len(last))
+		for _, req := range s.queue.Snapshot() {
+			pos++
+			if req.HasBuildProblem() {
+				continue
+			}
+			p := pos
+			current[req.GetId()] = p
+			if last[req.GetId()] == p {
+				continue
+			}
+			s.broadcast <- pb.JudgeUpdate_builder{
+				Id:            proto.String(req.GetId()),
+				Type:          proto.String("queued"),
+				Status:        proto.String(fmt.Sprintf("Queued (%d)", p)),
+				QueuePosition: &p,
+			}.Build()
+		}
+		last = current
+	}
+}
+
 func convertLanguagePB(l *pb.Language) Language {
 	return Language{
 		Name:           l.GetName(),
@@ -346,3 +490,25 @@ func convertResult(r Result) *pb.Result {
 		Log:    &r.Log,
 	}.Build()
 }
+
+func convertSourceFilesPB(f []*pb.SourceFile) []SourceFile {
+	rt := make([]SourceFile, 0, len(f))
+	for _, v := range f {
+		rt = append(rt, SourceFile{
+			Name:    v.GetName(),
+			Content: v.GetContent(),
+		})
+	}
+	return rt
+}
+
+func convertSourceFiles(f []SourceFile) []*pb.SourceFile {
+	rt := make([]*pb.SourceFile, 0, len(f))
+	for _, v := range f {
+		rt = append(rt, pb.SourceFile_builder{
+			Name:    &v.Name,
+			Content: v.Content,
+		}.Build())
+	}
+	return rt
+}
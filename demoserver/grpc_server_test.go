@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCheckSourceFiles(t *testing.T) {
+	tests := []struct {
+		source string
+		files  []string
+		ok     bool
+	}{
+		{"a.cc", nil, true},
+		{"a.cc", []string{"a.h", "lib/b.h"}, true},
+		{"", nil, false},
+		{"../a.cc", nil, false},
+		{"/w/a.cc", nil, false},
+		{"a.cc", []string{"../../etc/passwd"}, false},
+		{"a.cc", []string{"lib/../../a.h"}, false},
+		{"a.cc", []string{"./a.h"}, false},
+		{"a.cc", []string{"/etc/passwd"}, false},
+		{"a.cc", []string{"..\\a.h"}, false},
+		{"a.cc", []string{"."}, false},
+		{"a.cc", []string{"a.cc"}, false},
+		{"a.cc", []string{"a.h", "a.h"}, false},
+	}
+	for _, tt := range tests {
+		files := make([]*pb.SourceFile, 0, len(tt.files))
+		for _, name := range tt.files {
+			files = append(files, pb.SourceFile_builder{Name: proto.String(name)}.Build())
+		}
+		req := pb.SubmitRequest_builder{
+			Language: pb.Language_builder{SourceFileName: proto.String(tt.source)}.Build(),
+			Files:    files,
+		}.Build()
+		if err := checkSourceFiles(req); (err == nil) != tt.ok {
+			t.Errorf("checkSourceFiles(%q, %q) error = %v, want ok %v", tt.source, tt.files, err, tt.ok)
+		}
+	}
+}
@@ -9,7 +9,12 @@ import (
 	_ "net/http/pprof" // for pprof
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/criyle/go-judge-demo/internal/ratelimit"
+	"github.com/criyle/go-judge-demo/internal/tlsfile"
 	"github.com/criyle/go-judge-demo/pb"
 	execpb "github.com/criyle/go-judge/pb"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
@@ -22,11 +27,8 @@ import (
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zapgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
-	"google.golang.org/grpc/status"
 )
 
 const (
@@ -35,6 +37,47 @@ const (
 	envToken      = "TOKEN"
 	envRelease    = "RELEASE"
 	envMongoURI   = "MONGODB_URI"
+	envAdminUsers = "ADMIN_USERS"
+	envTokenFile  = "TOKEN_FILE"
+	envTLSCert    = "TLS_CERT"
+	envTLSKey     = "TLS_KEY"
+	envTLSCA      = "TLS_CA"
+	envTLSClient  = "TLS_CLIENT_CA"
+	envTLSServer  = "TLS_SERVER_NAME"
+
+	envExecTLSCert   = "EXEC_TLS_CERT"
+	envExecTLSKey    = "EXEC_TLS_KEY"
+	envExecTLSCA     = "EXEC_TLS_CA"
+	envExecTLSServer = "EXEC_TLS_SERVER_NAME"
+
+	envSubmitRate        = "SUBMIT_RATE"
+	envShellMaxPerCaller = "SHELL_MAX_PER_CALLER"
+	envShellMax          = "SHELL_MAX"
+	envQueueMax          = "QUEUE_MAX"
+	envUpdateReplay      = "UPDATE_REPLAY"
+	envRunTimeout        = "RUN_TIMEOUT"
+	envShellGrace        = "SHELL_GRACE"
+	envShellIdle         = "SHELL_IDLE"
+	envShellUploadMax    = "SHELL_UPLOAD_MAX"
+	envShellArchiveMax   = "SHELL_ARCHIVE_MAX"
+	envShellWorkspaceMax = "SHELL_WORKSPACE_MAX"
+	envShellArchiveKeep  = "SHELL_ARCHIVE_KEEP"
+	envShellProfiles     = "SHELL_PROFILES"
+
+	defaultSubmitRate        = "60/1m"
+	defaultShellMaxPerCaller = 3
+	defaultShellMax          = 50
+	defaultQueueMax          = 256
+	defaultUpdateReplay      = 1024
+	defaultRunTimeout        = time.Minute
+	defaultShellGrace        = time.Minute
+	defaultShellIdle         = 10 * time.Minute
+	defaultShellUploadMax    = 16 << 20
+	defaultShellArchiveMax   = 16 << 20
+	defaultShellWorkspaceMax = 8 << 20
+	defaultShellArchiveKeep  = 7 * 24 * time.Hour
+
+	maxRecvMsgSize = 64 << 20 // generated test data from judgers
 )
 
 func InterceptorLogger(l *zap.Logger) logging.Logger {
@@ -93,6 +136,25 @@ func main() {
 
 	db := getDB()
 	token := os.Getenv(envToken)
+	// TLS_CA and TLS_SERVER_NAME verify demoserver on its clients, the exec server
+	// is dialed with its own settings
+	for _, name := range []string{envTLSCA, envTLSServer} {
+		if os.Getenv(name) != "" {
+			log.Fatalln("tls", name, "is not used by demoserver, set", "EXEC_"+name, "to dial the exec server")
+		}
+	}
+	tf, err := tlsfile.New(os.Getenv(envTLSCert), os.Getenv(envTLSKey), "")
+	if err != nil {
+		log.Fatalln("tls", err)
+	}
+	clientCA, err := tlsfile.New("", "", os.Getenv(envTLSClient))
+	if err != nil {
+		log.Fatalln("tls client ca", err)
+	}
+	execTF, err := tlsfile.New(os.Getenv(envExecTLSCert), os.Getenv(envExecTLSKey), os.Getenv(envExecTLSCA))
+	if err != nil {
+		log.Fatalln("tls exec server", err)
+	}
 	grpcAddr := os.Getenv(envGRPCAddr)
 	if grpcAddr == "" {
 		grpcAddr = ":5081"
@@ -101,8 +163,41 @@ func main() {
 	if execServerAddr == "" {
 		execServerAddr = "localhost:5051"
 	}
-	execClient := createExecClient(execServerAddr, token, logger)
-	ds := newDemoServer(db, execClient, logger)
+	execClient := createExecClient(execServerAddr, token, execTF, logger)
+	conf := demoConfig{
+		ShellMaxPerCaller: envInt(envShellMaxPerCaller, defaultShellMaxPerCaller),
+		ShellMax:          envInt(envShellMax, defaultShellMax),
+		QueueMax:          envInt(envQueueMax, defaultQueueMax),
+		UpdateReplay:      envInt(envUpdateReplay, defaultUpdateReplay),
+		RunTimeout:        envDuration(envRunTimeout, defaultRunTimeout),
+		ShellGrace:        envDuration(envShellGrace, defaultShellGrace),
+		ShellIdle:         envDuration(envShellIdle, defaultShellIdle),
+		ShellUploadMax:    envInt(envShellUploadMax, defaultShellUploadMax),
+		ShellArchiveMax:   envInt(envShellArchiveMax, defaultShellArchiveMax),
+		ShellWorkspaceMax: envInt(envShellWorkspaceMax, defaultShellWorkspaceMax),
+		ShellArchiveKeep:  envDuration(envShellArchiveKeep, defaultShellArchiveKeep),
+	}
+	if a := os.Getenv(envAdminUsers); a != "" {
+		conf.Admins = strings.Split(a, ",")
+	}
+	submitRate, ok := os.LookupEnv(envSubmitRate)
+	if !ok {
+		submitRate = defaultSubmitRate
+	}
+	if conf.SubmitRate, conf.SubmitBurst, err = ratelimit.ParseRate(submitRate); err != nil {
+		log.Fatalln(envSubmitRate, err)
+	}
+	if conf.ShellProfiles, err = loadShellProfiles(os.Getenv(envShellProfiles)); err != nil {
+		log.Fatalln(envShellProfiles, err)
+	}
+	ds := newDemoServer(db, execClient, logger, conf)
+
+	if len(os.Args) > 1 && os.Args[1] == "import-problem" {
+		if err := importProblemCmd(ds, os.Args[2:]); err != nil {
+			log.Fatalln("import-problem", err)
+		}
+		return
+	}
 
 	var grpcServer *grpc.Server
 	prom := grpc_prometheus.NewServerMetrics(grpc_prometheus.WithServerHandlingTimeHistogram())
@@ -118,15 +213,29 @@ func main() {
 		logging.UnaryServerInterceptor(InterceptorLogger(logger)),
 		grpc_recovery.UnaryServerInterceptor(),
 	}
+	tokens, err := loadTokens(os.Getenv(envTokenFile), token)
+	if err != nil {
+		log.Fatalln("load tokens", err)
+	}
 	if token != "" {
-		authFunc := grpcTokenAuth(token)
-		streamMiddleware = append(streamMiddleware, grpc_auth.StreamServerInterceptor(authFunc))
-		unaryMiddleware = append(unaryMiddleware, grpc_auth.UnaryServerInterceptor(authFunc))
+		logger.Warn("the shared " + envToken + " has both gateway and judger roles, set " + envTokenFile + " with a token per service for role separation")
+	}
+	if len(tokens) > 0 {
+		authFunc := grpcTokenAuth(tokens)
+		streamMiddleware = append(streamMiddleware, grpc_auth.StreamServerInterceptor(authFunc), authzStreamInterceptor)
+		unaryMiddleware = append(unaryMiddleware, grpc_auth.UnaryServerInterceptor(authFunc), authzUnaryInterceptor)
 	}
-	grpcServer = grpc.NewServer(
+	serverOpts := []grpc.ServerOption{
 		grpc.ChainStreamInterceptor(streamMiddleware...),
 		grpc.ChainUnaryInterceptor(unaryMiddleware...),
-	)
+		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+	}
+	if tf.HasCert() {
+		serverOpts = append(serverOpts, grpc.Creds(tf.ServerCredentials(clientCA)))
+	} else if clientCA != nil {
+		log.Fatalln("tls", envTLSClient, "requires", envTLSCert)
+	}
+	grpcServer = grpc.NewServer(serverOpts...)
 	pb.RegisterDemoBackendServer(grpcServer, ds)
 
 	lis, err := net.Listen("tcp", grpcAddr)
@@ -152,33 +261,44 @@ func main() {
 	grpcServer.GracefulStop()
 }
 
-func grpcTokenAuth(token string) func(context.Context) (context.Context, error) {
-	return func(ctx context.Context) (context.Context, error) {
-		reqToken, err := grpc_auth.AuthFromMD(ctx, "bearer")
-		if err != nil {
-			return nil, err
-		}
-		if reqToken != token {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
-		}
-		return ctx, nil
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalln(name, err)
+	}
+	return i
+}
+
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalln(name, err)
 	}
+	return d
 }
 
-func createExecClient(execServer, token string, logger *zap.Logger) execpb.ExecutorClient {
-	conn, err := createGRPCConnection(execServer, token, logger)
+func createExecClient(execServer, token string, tf *tlsfile.Files, logger *zap.Logger) execpb.ExecutorClient {
+	conn, err := createGRPCConnection(execServer, token, tf, logger)
 	if err != nil {
 		log.Fatalln("client", err)
 	}
 	return execpb.NewExecutorClient(conn)
 }
 
-func createGRPCConnection(addr, token string, logger *zap.Logger) (*grpc.ClientConn, error) {
+func createGRPCConnection(addr, token string, tf *tlsfile.Files, logger *zap.Logger) (*grpc.ClientConn, error) {
 	prom := grpc_prometheus.NewClientMetrics(grpc_prometheus.WithClientHandlingTimeHistogram())
 	prometheus.MustRegister(prom)
 	grpclog.SetLoggerV2(zapgrpc.NewLogger(logger))
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(tlsfile.DialCredentials(tf, os.Getenv(envExecTLSServer))),
 		grpc.WithChainUnaryInterceptor(
 			prom.UnaryClientInterceptor(),
 			logging.UnaryClientInterceptor(InterceptorLogger(logger)),
@@ -188,17 +308,18 @@ func createGRPCConnection(addr, token string, logger *zap.Logger) (*grpc.ClientC
 			logging.StreamClientInterceptor(InterceptorLogger(logger)),
 		)}
 	if token != "" {
-		opts = append(opts, grpc.WithPerRPCCredentials(newTokenAuth(token)))
+		opts = append(opts, grpc.WithPerRPCCredentials(newTokenAuth(token, tf != nil)))
 	}
 	return grpc.NewClient(addr, opts...)
 }
 
 type tokenAuth struct {
-	token string
+	token  string
+	secure bool
 }
 
-func newTokenAuth(token string) credentials.PerRPCCredentials {
-	return &tokenAuth{token: token}
+func newTokenAuth(token string, secure bool) credentials.PerRPCCredentials {
+	return &tokenAuth{token: token, secure: secure}
 }
 
 // Return value is mapped to request headers.
@@ -208,6 +329,6 @@ func (t *tokenAuth) GetRequestMetadata(ctx context.Context, in ...string) (map[s
 	}, nil
 }
 
-func (*tokenAuth) RequireTransportSecurity() bool {
-	return false
+func (t *tokenAuth) RequireTransportSecurity() bool {
+	return t.secure
 }
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// checkProblemAdmin guards the problem changes and the hidden test data
+func checkProblemAdmin(ctx context.Context) error {
+	if !isAdmin(ctx) {
+		return status.Errorf(codes.PermissionDenied, "problem changes and test data require admin role")
+	}
+	return nil
+}
+
+func (s *demoServer) CreateProblem(ctx context.Context, req *pb.Problem) (*pb.Problem, error) {
+	if err := checkProblemAdmin(ctx); err != nil {
+		return nil, err
+	}
+	p := convertProblemPB(req)
+	if err := s.storeTestData(ctx, p, nil); err != nil {
+		return nil, err
+	}
+	p, err := s.db.AddProblem(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return convertProblem(p), nil
+}
+
+func (s *demoServer) GetProblem(ctx context.Context, req *pb.GetProblemRequest) (*pb.Problem, error) {
+	p, err := s.getProblem(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetTestData() {
+		if err := checkProblemAdmin(ctx); err != nil {
+			return nil, err
+		}
+		if err := s.loadTestData(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+	return convertProblem(p), nil
+}
+
+func (s *demoServer) ListProblems(ctx context.Context, req *pb.ListProblemsRequest) (*pb.ListProblemsResponse, error) {
+	ps, err := s.db.QueryProblems(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	rt := make([]*pb.Problem, 0, len(ps))
+	for _, p := range ps {
+		rt = append(rt, convertProblem(&p))
+	}
+	return pb.ListProblemsResponse_builder{Problems: rt}.Build(), nil
+}
+
+func (s *demoServer) UpdateProblem(ctx context.Context, req *pb.Problem) (*pb.Problem, error) {
+	if err := checkProblemAdmin(ctx); err != nil {
+		return nil, err
+	}
+	prev, err := s.getProblem(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p := convertProblemPB(req)
+	p.ID = prev.ID
+	if err := s.storeTestData(ctx, p, prev); err != nil {
+		return nil, err
+	}
+	p, err = s.db.UpdateProblem(ctx, p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "problem %q not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertProblem(p), nil
+}
+
+// getProblem loads the problem and maps lookup failures to gRPC status
+func (s *demoServer) getProblem(ctx context.Context, id string) (*Problem, error) {
+	if _, err := bson.ObjectIDFromHex(id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid problem id %q", id)
+	}
+	p, err := s.db.GetProblem(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "problem %q not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// storeTestData moves test case content into blob store. Test cases with hash only
+// are kept so clients could update a problem without uploading test data again, the
+// hash must be the test data of the previous problem as the blob store also holds
+// other content, e.g. shell archives.
+func (s *demoServer) storeTestData(ctx context.Context, p *Problem, prev *Problem) error {
+	known := make(map[string]bool)
+	if prev != nil {
+		for _, tc := range prev.TestCases {
+			known[tc.InputHash] = true
+			known[tc.AnswerHash] = true
+		}
+	}
+	for i := range p.TestCases {
+		tc := &p.TestCases[i]
+		if (tc.Input == "" && tc.InputHash != "" && !known[tc.InputHash]) ||
+			(tc.Answer == "" && tc.AnswerHash != "" && !known[tc.AnswerHash]) {
+			return status.Errorf(codes.InvalidArgument, "test case %d: unknown test data hash", i)
+		}
+		if tc.InputHash == "" || tc.Input != "" {
+			h, err := s.blob.Put(ctx, []byte(tc.Input))
+			if err != nil {
+				return err
+			}
+			tc.InputHash, tc.Input = h, ""
+		}
+		if tc.AnswerHash == "" || tc.Answer != "" {
+			h, err := s.blob.Put(ctx, []byte(tc.Answer))
+			if err != nil {
+				return err
+			}
+			tc.AnswerHash, tc.Answer = h, ""
+		}
+	}
+	return nil
+}
+
+// loadTestData fills test case content from blob store
+func (s *demoServer) loadTestData(ctx context.Context, p *Problem) error {
+	for i := range p.TestCases {
+		tc := &p.TestCases[i]
+		if tc.InputHash != "" {
+			c, err := s.blob.Get(ctx, tc.InputHash)
+			if err != nil {
+				return err
+			}
+			tc.Input = string(c)
+		}
+		if tc.AnswerHash != "" {
+			c, err := s.blob.Get(ctx, tc.AnswerHash)
+			if err != nil {
+				return err
+			}
+			tc.Answer = string(c)
+		}
+	}
+	return nil
+}
+
+func convertProblemPB(p *pb.Problem) *Problem {
+	rt := &Problem{
+		Title:       p.GetTitle(),
+		Statement:   p.GetStatement(),
+		TimeLimit:   p.GetTimeLimit(),
+		MemoryLimit: p.GetMemoryLimit(),
+		TestCases:   convertInputAnswersPB(p.GetTestCases()),
+	}
+	if p.HasChecker() {
+		rt.Checker = &Checker{
+			Lang:   convertLanguagePB(p.GetChecker().GetLanguage()),
+			Source: p.GetChecker().GetSource(),
+			Files:  convertSourceFilesPB(p.GetChecker().GetFiles()),
+		}
+	}
+	return rt
+}
+
+func convertProblem(p *Problem) *pb.Problem {
+	id := p.ID.Hex()
+	rt := pb.Problem_builder{
+		Id:          &id,
+		Title:       &p.Title,
+		Statement:   &p.Statement,
+		TimeLimit:   &p.TimeLimit,
+		MemoryLimit: &p.MemoryLimit,
+		Checker:     convertChecker(p.Checker),
+		TestCases:   convertInputAnswers(p.TestCases),
+		BuildStatus: &p.BuildStatus,
+		BuildLog:    &p.BuildLog,
+	}.Build()
+	if p.Date != nil {
+		rt.SetDate(timestamppb.New(*p.Date))
+	}
+	return rt
+}
+
+func convertChecker(c *Checker) *pb.Checker {
+	if c == nil {
+		return nil
+	}
+	return pb.Checker_builder{
+		Language: convertLanguage(c.Lang),
+		Source:   &c.Source,
+		Files:    convertSourceFiles(c.Files),
+	}.Build()
+}
+
+func convertInputAnswersPB(ia []*pb.InputAnswer) []InputAnswer {
+	rt := make([]InputAnswer, 0, len(ia))
+	for _, v := range ia {
+		rt = append(rt, InputAnswer{
+			Input:      v.GetInput(),
+			Answer:     v.GetAnswer(),
+			InputHash:  v.GetInputHash(),
+			AnswerHash: v.GetAnswerHash(),
+		})
+	}
+	return rt
+}
+
+func convertInputAnswers(ia []InputAnswer) []*pb.InputAnswer {
+	rt := make([]*pb.InputAnswer, 0, len(ia))
+	for _, v := range ia {
+		rt = append(rt, pb.InputAnswer_builder{
+			Input:      &v.Input,
+			Answer:     &v.Answer,
+			InputHash:  &v.InputHash,
+			AnswerHash: &v.AnswerHash,
+		}.Build())
+	}
+	return rt
+}
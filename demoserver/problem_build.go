@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BuildProblem queues a test data build on the judgers: the generator script is run
+// to produce inputs which are checked by the validator and answered by the solution
+func (s *demoServer) BuildProblem(ctx context.Context, req *pb.BuildProblemRequest) (*pb.BuildProblemResponse, error) {
+	if err := checkProblemAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if !req.HasSolution() {
+		return nil, status.Errorf(codes.InvalidArgument, "solution is required")
+	}
+	if len(req.GetGenerators()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one generator is required")
+	}
+	names := make(map[string]bool)
+	for _, g := range req.GetGenerators() {
+		if g.GetName() == "" || names[g.GetName()] {
+			return nil, status.Errorf(codes.InvalidArgument, "generator name %q is empty or duplicated", g.GetName())
+		}
+		names[g.GetName()] = true
+	}
+	if _, err := s.getProblem(ctx, req.GetProblemId()); err != nil {
+		return nil, err
+	}
+	slot, err := s.queue.Reserve()
+	if err != nil {
+		return nil, err
+	}
+	defer slot.Cancel()
+	if err := s.db.SetProblemBuild(ctx, req.GetProblemId(), "Queued", "", nil); err != nil {
+		return nil, err
+	}
+	id := bson.NewObjectID().Hex()
+	slot.Push(pb.JudgeClientRequest_builder{
+		Id:           &id,
+		BuildProblem: req,
+	}.Build(), classBulk, callerKey(ctx))
+	s.logger.Debug("build problem", zap.String("id", id), zap.String("problemId", req.GetProblemId()))
+	return pb.BuildProblemResponse_builder{Id: &id}.Build(), nil
+}
+
+// judgeBuild receives the generated test data from judger, test cases of the problem
+// are replaced only if all of them were generated successfully
+func (s *demoServer) judgeBuild(js pb.DemoBackend_JudgeServer, req *pb.JudgeClientRequest) error {
+	ctx := js.Context()
+	problemID := req.GetBuildProblem().GetProblemId()
+	var tests []InputAnswer
+	for {
+		resp, err := js.Recv()
+		s.logger.Info("build response", zap.String("id", req.GetId()), zap.String("status", resp.GetStatus()), zap.Error(err))
+		if err != nil {
+			return err
+		}
+		for _, ia := range resp.GetInputAnswer() {
+			inputHash, err := s.blob.Put(ctx, []byte(ia.GetInput()))
+			if err != nil {
+				return err
+			}
+			answerHash, err := s.blob.Put(ctx, []byte(ia.GetAnswer()))
+			if err != nil {
+				return err
+			}
+			tests = append(tests, InputAnswer{InputHash: inputHash, AnswerHash: answerHash})
+		}
+		if resp.GetType() != "finished" {
+			if err := s.db.SetProblemBuild(ctx, problemID, resp.GetStatus(), "", nil); err != nil {
+				s.logger.Warn("build status", zap.Error(err))
+			}
+			continue
+		}
+		log := resp.GetLog()
+		if resp.GetStatus() != "Accepted" {
+			tests = nil
+		} else {
+			log += fmt.Sprintf("%d tests generated\n", len(tests))
+		}
+		if err := s.db.SetProblemBuild(ctx, problemID, resp.GetStatus(), log, tests); err != nil {
+			s.logger.Error("build result", zap.String("problemId", problemID), zap.Error(err))
+		}
+		return nil
+	}
+}
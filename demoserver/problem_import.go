@@ -0,0 +1,434 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"github.com/goccy/go-yaml"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxImportFileSize = 64 << 20  // 64m per file in package
+	maxImportSize     = 256 << 20 // 256m in total read from package
+	maxImportFiles    = 4096
+
+	polygonManifest = "problem.xml"
+	plainManifest   = "problem.yaml"
+)
+
+// checkerLanguage compiles testlib style checkers in imported packages
+var checkerLanguage = Language{
+	Name:           "c++",
+	SourceFileName: "checker.cc",
+	CompileCmd:     "/usr/bin/g++ -O2 -std=c++17 -o checker checker.cc",
+	Executables:    "checker",
+	RunCmd:         "checker",
+}
+
+// plainProblem is the problem.yaml manifest of a plain directory package.
+// Tests are tests/*.in with the answer in the .ans (or .out) file of the same name.
+type plainProblem struct {
+	Title       string `yaml:"title"`
+	Statement   string `yaml:"statement"`   // path of statement file
+	TimeLimit   uint64 `yaml:"timeLimit"`   // ms
+	MemoryLimit uint64 `yaml:"memoryLimit"` // kb
+	Checker     string `yaml:"checker"`     // path of testlib checker source
+}
+
+// polygonProblem is the subset of polygon problem.xml used for import
+type polygonProblem struct {
+	Names []struct {
+		Language string `xml:"language,attr"`
+		Value    string `xml:"value,attr"`
+	} `xml:"names>name"`
+	Testsets []struct {
+		Name          string `xml:"name,attr"`
+		TimeLimit     uint64 `xml:"time-limit"`   // ms
+		MemoryLimit   uint64 `xml:"memory-limit"` // bytes
+		TestCount     int    `xml:"test-count"`
+		InputPattern  string `xml:"input-path-pattern"`
+		AnswerPattern string `xml:"answer-path-pattern"`
+	} `xml:"judging>testset"`
+	Checker struct {
+		Sources []struct {
+			Path string `xml:"path,attr"`
+		} `xml:"source"`
+	} `xml:"assets>checker"`
+}
+
+var (
+	errImportTooLarge = errors.New("uncompressed size too large")
+	errImportTooMany  = errors.New("too many files")
+)
+
+func (s *demoServer) ImportProblem(ctx context.Context, req *pb.ImportProblemRequest) (*pb.Problem, error) {
+	if err := checkProblemAdmin(ctx); err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(req.GetPackage()), int64(len(req.GetPackage())))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "package: %v", err)
+	}
+	if len(zr.File) > maxImportFiles {
+		return nil, status.Errorf(codes.InvalidArgument, "package: %v", errImportTooMany)
+	}
+	p, err := s.importProblem(ctx, zr, req.GetId())
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "package: %v", err)
+	}
+	return convertProblem(p), nil
+}
+
+// importProblem parses the package, validates the checker and stores it as a new
+// problem or replaces the problem with id
+func (s *demoServer) importProblem(ctx context.Context, fsys fs.FS, id string) (*Problem, error) {
+	fsys, err := packageRoot(fsys)
+	if err != nil {
+		return nil, err
+	}
+	pr := newPackageReader(fsys)
+	var p *Problem
+	if _, err := fs.Stat(fsys, polygonManifest); err == nil {
+		p, err = parsePolygonPackage(pr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		p, err = parsePlainPackage(pr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.Checker != nil {
+		if err := s.validateChecker(ctx, p.Checker); err != nil {
+			return nil, fmt.Errorf("checker: %w", err)
+		}
+	}
+	if err := s.storeTestData(ctx, p, nil); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return s.db.AddProblem(ctx, p)
+	}
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid problem id %q", id)
+	}
+	p.ID = &oid
+	p, err = s.db.UpdateProblem(ctx, p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "problem %q not found", id)
+	}
+	return p, err
+}
+
+// packageRoot descends into the single top level directory if the manifest is not at root
+func packageRoot(fsys fs.FS) (fs.FS, error) {
+	for _, m := range []string{polygonManifest, plainManifest} {
+		if _, err := fs.Stat(fsys, m); err == nil {
+			return fsys, nil
+		}
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return fs.Sub(fsys, entries[0].Name())
+	}
+	return fsys, nil
+}
+
+func parsePlainPackage(pr *packageReader) (*Problem, error) {
+	var m plainProblem
+	if c, err := pr.ReadFile(plainManifest); err == nil {
+		if err := yaml.Unmarshal(c, &m); err != nil {
+			return nil, fmt.Errorf("%s: %w", plainManifest, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	p := &Problem{
+		Title:       m.Title,
+		TimeLimit:   m.TimeLimit,
+		MemoryLimit: m.MemoryLimit,
+	}
+	if m.Statement != "" {
+		c, err := pr.ReadFile(m.Statement)
+		if err != nil {
+			return nil, err
+		}
+		p.Statement = string(c)
+	}
+	checker := m.Checker
+	if checker == "" {
+		if _, err := fs.Stat(pr.fsys, "checker.cpp"); err == nil {
+			checker = "checker.cpp"
+		}
+	}
+	if checker != "" {
+		c, err := packageChecker(pr, checker)
+		if err != nil {
+			return nil, err
+		}
+		p.Checker = c
+	}
+
+	inputs, err := fs.Glob(pr.fsys, "tests/*.in")
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(inputs, compareTestName)
+	for _, in := range inputs {
+		base := strings.TrimSuffix(in, ".in")
+		ans := base + ".ans"
+		if _, err := fs.Stat(pr.fsys, ans); err != nil {
+			ans = base + ".out"
+		}
+		tc, err := packageTestCase(pr, in, ans)
+		if err != nil {
+			return nil, err
+		}
+		p.TestCases = append(p.TestCases, tc)
+	}
+	if len(p.TestCases) == 0 {
+		return nil, errors.New("no test found in tests/*.in")
+	}
+	return p, nil
+}
+
+func parsePolygonPackage(pr *packageReader) (*Problem, error) {
+	c, err := pr.ReadFile(polygonManifest)
+	if err != nil {
+		return nil, err
+	}
+	var m polygonProblem
+	if err := xml.Unmarshal(c, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", polygonManifest, err)
+	}
+	p := new(Problem)
+	for _, n := range m.Names {
+		if p.Title == "" || n.Language == "english" {
+			p.Title = n.Value
+		}
+	}
+	if c, err := pr.ReadFile("statement.md"); err == nil {
+		p.Statement = string(c)
+	}
+	if len(m.Testsets) == 0 {
+		return nil, fmt.Errorf("%s: no testset", polygonManifest)
+	}
+	ts := m.Testsets[0]
+	for _, t := range m.Testsets {
+		if t.Name == "tests" {
+			ts = t
+		}
+	}
+	p.TimeLimit = ts.TimeLimit
+	p.MemoryLimit = ts.MemoryLimit >> 10
+	if ts.InputPattern == "" {
+		ts.InputPattern = "tests/%02d"
+	}
+	if ts.AnswerPattern == "" {
+		ts.AnswerPattern = "tests/%02d.a"
+	}
+	for i := 1; i <= ts.TestCount; i++ {
+		tc, err := packageTestCase(pr, fmt.Sprintf(ts.InputPattern, i), fmt.Sprintf(ts.AnswerPattern, i))
+		if err != nil {
+			return nil, fmt.Errorf("%w (tests are only included in full packages)", err)
+		}
+		p.TestCases = append(p.TestCases, tc)
+	}
+	if len(p.TestCases) == 0 {
+		return nil, fmt.Errorf("%s: no test", polygonManifest)
+	}
+
+	checker := "check.cpp"
+	if len(m.Checker.Sources) > 0 {
+		checker = m.Checker.Sources[0].Path
+	}
+	if _, err := fs.Stat(pr.fsys, checker); err == nil {
+		c, err := packageChecker(pr, checker)
+		if err != nil {
+			return nil, err
+		}
+		p.Checker = c
+	}
+	return p, nil
+}
+
+// packageChecker reads the checker source together with testlib.h next to it or at package root
+func packageChecker(pr *packageReader, name string) (*Checker, error) {
+	src, err := pr.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &Checker{
+		Lang:   checkerLanguage,
+		Source: string(src),
+	}
+	for _, t := range []string{path.Join(path.Dir(name), "testlib.h"), "testlib.h"} {
+		if h, err := pr.ReadFile(t); err == nil {
+			c.Files = append(c.Files, SourceFile{Name: "testlib.h", Content: h})
+			break
+		}
+	}
+	return c, nil
+}
+
+func packageTestCase(pr *packageReader, input, answer string) (InputAnswer, error) {
+	in, err := pr.ReadFile(input)
+	if err != nil {
+		return InputAnswer{}, err
+	}
+	ans, err := pr.ReadFile(answer)
+	if err != nil {
+		return InputAnswer{}, err
+	}
+	return InputAnswer{Input: string(in), Answer: string(ans)}, nil
+}
+
+// packageReader reads the package files within the total size and file count,
+// the sizes declared in zip headers are not trusted
+type packageReader struct {
+	fsys      fs.FS
+	remaining int64
+	files     int
+}
+
+func newPackageReader(fsys fs.FS) *packageReader {
+	return &packageReader{fsys: fsys, remaining: maxImportSize, files: maxImportFiles}
+}
+
+func (r *packageReader) ReadFile(name string) ([]byte, error) {
+	if r.files <= 0 {
+		return nil, errImportTooMany
+	}
+	f, err := r.fsys.Open(path.Clean(name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := io.ReadAll(io.LimitReader(f, min(maxImportFileSize, r.remaining)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(c) > maxImportFileSize {
+		return nil, fmt.Errorf("%s: file too large", name)
+	}
+	if int64(len(c)) > r.remaining {
+		return nil, errImportTooLarge
+	}
+	r.remaining -= int64(len(c))
+	r.files--
+	return c, nil
+}
+
+// compareTestName orders tests/2.in before tests/10.in
+func compareTestName(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// validateChecker compiles the checker once through the exec server
+func (s *demoServer) validateChecker(ctx context.Context, c *Checker) error {
+	copyIn := map[string]*execpb.Request_File{
+		c.Lang.SourceFileName: execpb.Request_File_builder{
+			Memory: execpb.Request_MemoryFile_builder{Content: []byte(c.Source)}.Build(),
+		}.Build(),
+	}
+	for _, f := range c.Files {
+		copyIn[f.Name] = execpb.Request_File_builder{
+			Memory: execpb.Request_MemoryFile_builder{Content: f.Content}.Build(),
+		}.Build()
+	}
+	args := strings.Fields(c.Lang.CompileCmd)
+	ret, err := s.client.Exec(ctx, execpb.Request_builder{
+		Cmd: []*execpb.Request_CmdType{execpb.Request_CmdType_builder{
+			Args: args,
+			Env:  []string{"PATH=/usr/local/bin:/usr/bin:/bin", "HOME=/tmp"},
+			Files: []*execpb.Request_File{
+				execpb.Request_File_builder{Memory: execpb.Request_MemoryFile_builder{Content: []byte{}}.Build()}.Build(),
+				execpb.Request_File_builder{Pipe: execpb.Request_PipeCollector_builder{Name: "stdout", Max: 4096}.Build()}.Build(),
+				execpb.Request_File_builder{Pipe: execpb.Request_PipeCollector_builder{Name: "stderr", Max: 4096}.Build()}.Build(),
+			},
+			CpuTimeLimit:   uint64(30 * time.Second),
+			ClockTimeLimit: uint64(60 * time.Second),
+			MemoryLimit:    256 << 20,
+			ProcLimit:      100,
+			CopyIn:         copyIn,
+			CopyOut:        []*execpb.Request_CmdCopyOutFile{execpb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()},
+		}.Build()},
+	}.Build())
+	if err != nil {
+		return err
+	}
+	if ret.GetError() != "" {
+		return errors.New(ret.GetError())
+	}
+	r := ret.GetResults()[0]
+	if r.GetStatus() != execpb.Response_Result_Accepted {
+		return fmt.Errorf("compile %v: %s", r.GetStatus(), r.GetFiles()["stderr"])
+	}
+	return nil
+}
+
+// importProblemCmd implements `demoserver import-problem [-id id] <package>`,
+// the package is either a zip file or a directory
+func importProblemCmd(ds *demoServer, args []string) error {
+	fset := flag.NewFlagSet("import-problem", flag.ExitOnError)
+	id := fset.String("id", "", "replace the existing problem")
+	fset.Parse(args)
+	if fset.NArg() != 1 {
+		return errors.New("usage: demoserver import-problem [-id id] <package.zip | directory>")
+	}
+	name := fset.Arg(0)
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	var fsys fs.FS
+	if fi.IsDir() {
+		fsys = os.DirFS(name)
+	} else {
+		zr, err := zip.OpenReader(name)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		fsys = zr
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	p, err := ds.importProblem(ctx, fsys, *id)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported problem %s: %q with %d tests\n", p.ID.Hex(), p.Title, len(p.TestCases))
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// judgeQueue is the bounded queue of requests waiting for judgers, dispatched
+// by the scheduler
+type judgeQueue struct {
+	max int
+
+	mu       sync.Mutex
+	sched    *scheduler
+	reserved int
+
+	ready   chan struct{} // signaled when items are available
+	changed chan struct{} // signaled when positions changed
+}
+
+func newJudgeQueue(max int) *judgeQueue {
+	return &judgeQueue{
+		max:     max,
+		sched:   newScheduler(),
+		ready:   make(chan struct{}, 1),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// queueSlot is a reserved place in the queue, so the submission is only stored
+// after it is admitted
+type queueSlot struct {
+	q    *judgeQueue
+	once sync.Once
+}
+
+// Reserve admits a request or fails with ResourceExhausted if the queue is full
+func (q *judgeQueue) Reserve() (*queueSlot, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.max > 0 && q.sched.Len()+q.reserved >= q.max {
+		return nil, status.Errorf(codes.ResourceExhausted, "judge queue is full (%d)", q.max)
+	}
+	q.reserved++
+	return &queueSlot{q: q}, nil
+}
+
+// Push enqueues the request into the reserved slot and returns its 1-based position
+func (s *queueSlot) Push(req *pb.JudgeClientRequest, class priorityClass, owner string) int {
+	pos := 0
+	s.once.Do(func() {
+		s.q.mu.Lock()
+		s.q.reserved--
+		now := time.Now()
+		s.q.sched.Push(req, class, owner, now)
+		pos = s.q.sched.Position(req, now)
+		s.q.mu.Unlock()
+		s.q.signal()
+	})
+	return pos
+}
+
+// Cancel releases the slot if not pushed
+func (s *queueSlot) Cancel() {
+	s.once.Do(func() {
+		s.q.mu.Lock()
+		s.q.reserved--
+		s.q.mu.Unlock()
+	})
+}
+
+// Requeue puts the request back to the head regardless of the limit, used when the
+// judger failed before finishing it
+func (q *judgeQueue) Requeue(req *pb.JudgeClientRequest) {
+	q.mu.Lock()
+	q.sched.Requeue(req)
+	q.mu.Unlock()
+	q.signal()
+}
+
+// Pop blocks until a request is available or the context is done
+func (q *judgeQueue) Pop(ctx context.Context) (*pb.JudgeClientRequest, error) {
+	for {
+		q.mu.Lock()
+		if req := q.sched.Pop(time.Now()); req != nil {
+			remain := q.sched.Len()
+			q.mu.Unlock()
+			if remain > 0 {
+				// wake up other waiting judgers
+				q.signal()
+			} else {
+				q.notifyChanged()
+			}
+			return req, nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.ready:
+		}
+	}
+}
+
+// Snapshot returns the waiting requests in dispatch order
+func (q *judgeQueue) Snapshot() []*pb.JudgeClientRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sched.Order(time.Now())
+}
+
+// Changed is signaled when the queue positions may have changed
+func (q *judgeQueue) Changed() <-chan struct{} {
+	return q.changed
+}
+
+func (q *judgeQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	q.notifyChanged()
+}
+
+func (q *judgeQueue) notifyChanged() {
+	select {
+	case q.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/criyle/go-judge-demo/internal/ratelimit"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callerKey identifies the end user forwarded by apigateway, or the calling service
+func callerKey(ctx context.Context) string {
+	u := userFromContext(ctx)
+	switch {
+	case u.ID != "":
+		return "user:" + u.ID
+	case u.IP != "":
+		return "ip:" + u.IP
+	}
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return "service:" + p.Name
+	}
+	return "anonymous"
+}
+
+// rateLimiter is a token bucket per caller
+type rateLimiter struct {
+	*ratelimit.Limiter
+}
+
+func newRateLimiter(limit rate.Limit, burst int) *rateLimiter {
+	return &rateLimiter{ratelimit.NewLimiter(limit, burst)}
+}
+
+// Allow returns ResourceExhausted if the caller runs out of tokens
+func (l *rateLimiter) Allow(ctx context.Context, what string) error {
+	if l.Unlimited() {
+		return nil
+	}
+	key := callerKey(ctx)
+	if !l.Get(key).Allow() {
+		return status.Errorf(codes.ResourceExhausted, "%s rate limit exceeded for %s", what, key)
+	}
+	return nil
+}
+
+// concurrencyLimiter caps the number of concurrent sessions per caller and in total
+type concurrencyLimiter struct {
+	*ratelimit.Concurrency
+}
+
+func newConcurrencyLimiter(perCaller, total int) *concurrencyLimiter {
+	return &concurrencyLimiter{ratelimit.NewConcurrency(perCaller, total)}
+}
+
+// Acquire returns the release function, or ResourceExhausted if either cap is reached
+func (l *concurrencyLimiter) Acquire(ctx context.Context, what string) (func(), error) {
+	key := callerKey(ctx)
+	release, err := l.Concurrency.Acquire(key)
+	perCaller, total := l.Limits()
+	switch {
+	case errors.Is(err, ratelimit.ErrTotal):
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s (%d)", what, total)
+	case errors.Is(err, ratelimit.ErrPerKey):
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s for %s (%d)", what, key, perCaller)
+	}
+	return release, err
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Run submits and waits for the finished update. If it does not finish in time,
+// the latest update is returned so that the caller could follow the submission
+// by its id.
+func (s *demoServer) Run(ctx context.Context, req *pb.RunRequest) (*pb.JudgeUpdate, error) {
+	if !req.HasSubmit() {
+		return nil, status.Errorf(codes.InvalidArgument, "submit is required")
+	}
+	timeout := s.runTimeout
+	if t := time.Duration(req.GetTimeout()) * time.Second; t > 0 && t < timeout {
+		timeout = t
+	}
+	waiter := make(chan *pb.JudgeUpdate, 16)
+	resp, err := s.submit(ctx, req.GetSubmit(), waiter)
+	if err != nil {
+		return nil, err
+	}
+	latest := pb.JudgeUpdate_builder{
+		Id:            proto.String(resp.GetId()),
+		Type:          proto.String("queued"),
+		QueuePosition: proto.Uint32(resp.GetQueuePosition()),
+	}.Build()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-timer.C:
+			return latest, nil
+
+		case up := <-waiter:
+			latest = up
+			if up.GetType() == "finished" {
+				return up, nil
+			}
+		}
+	}
+}
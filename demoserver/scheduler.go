@@ -0,0 +1,241 @@
+package main
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+)
+
+// priorityClass is the scheduling class, lower value is dispatched first
+type priorityClass int
+
+const (
+	classInteractive priorityClass = iota
+	classContest
+	classRejudge
+	classBulk
+	numClasses
+)
+
+// priorityAging promotes a waiting request by one class per interval so that
+// lower classes are not starved by a steady stream of higher ones
+const priorityAging = time.Minute
+
+// queuePositionCheck is the interval to recompute the queue positions, which change
+// by aging without any queue operation
+const queuePositionCheck = priorityAging / 6
+
+var classNames = [numClasses]string{"interactive", "contest", "rejudge", "bulk"}
+
+func (c priorityClass) String() string {
+	if c >= 0 && c < numClasses {
+		return classNames[c]
+	}
+	return "unknown"
+}
+
+// convertPriorityPB maps the requested priority, PRIORITY_DEFAULT resolves to def
+func convertPriorityPB(p pb.Priority, def priorityClass) priorityClass {
+	switch p {
+	case pb.Priority_PRIORITY_INTERACTIVE:
+		return classInteractive
+	case pb.Priority_PRIORITY_CONTEST:
+		return classContest
+	case pb.Priority_PRIORITY_REJUDGE:
+		return classRejudge
+	case pb.Priority_PRIORITY_BULK:
+		return classBulk
+	}
+	return def
+}
+
+// queueItem is a waiting request with its scheduling state
+type queueItem struct {
+	req      *pb.JudgeClientRequest
+	class    priorityClass
+	owner    string    // fair share key, see callerKey
+	enqueued time.Time // for aging
+	tag      uint64    // virtual finish time within the class
+	seq      uint64    // arrival order to break ties
+}
+
+// scheduler dispatches requests by priority class, and within a class by
+// start-time fair queueing over the owners: each owner's requests get
+// consecutive virtual tags starting from the current virtual time of the class,
+// so an owner with many waiting requests interleaves with the others instead of
+// blocking them. It is not safe for concurrent use, see judgeQueue.
+type scheduler struct {
+	items    []*queueItem
+	requeued []*queueItem // dispatched before anything else
+
+	seq   uint64
+	vtime [numClasses]uint64            // tag of the last dispatched item
+	last  [numClasses]map[string]uint64 // last tag assigned to each owner
+}
+
+func newScheduler() *scheduler {
+	s := new(scheduler)
+	for i := range s.last {
+		s.last[i] = make(map[string]uint64)
+	}
+	return s
+}
+
+func (s *scheduler) Len() int {
+	return len(s.items) + len(s.requeued)
+}
+
+// Push adds a request at time now
+func (s *scheduler) Push(req *pb.JudgeClientRequest, class priorityClass, owner string, now time.Time) {
+	if class < 0 || class >= numClasses {
+		class = classBulk
+	}
+	tag := max(s.vtime[class], s.last[class][owner]) + 1
+	s.last[class][owner] = tag
+	s.seq++
+	s.items = append(s.items, &queueItem{
+		req:      req,
+		class:    class,
+		owner:    owner,
+		enqueued: now,
+		tag:      tag,
+		seq:      s.seq,
+	})
+}
+
+// Requeue puts a dispatched request back to be dispatched next
+func (s *scheduler) Requeue(req *pb.JudgeClientRequest) {
+	s.requeued = append(s.requeued, &queueItem{req: req})
+}
+
+// Pop removes and returns the next request at time now, or nil if empty
+func (s *scheduler) Pop(now time.Time) *pb.JudgeClientRequest {
+	if len(s.requeued) > 0 {
+		it := s.requeued[0]
+		s.requeued[0] = nil
+		s.requeued = s.requeued[1:]
+		return it.req
+	}
+	i := s.next(now)
+	if i < 0 {
+		return nil
+	}
+	it := s.items[i]
+	s.items = append(s.items[:i], s.items[i+1:]...)
+
+	// advance the virtual time and forget the owners no longer ahead of it
+	s.vtime[it.class] = max(s.vtime[it.class], it.tag)
+	for o, t := range s.last[it.class] {
+		if t <= s.vtime[it.class] {
+			delete(s.last[it.class], o)
+		}
+	}
+	return it.req
+}
+
+// next returns the index of the item to dispatch: the class is chosen by its
+// oldest item's aged priority, then the smallest tag within the class
+func (s *scheduler) next(now time.Time) int {
+	var oldest [numClasses]time.Time
+	var present [numClasses]bool
+	for _, it := range s.items {
+		if !present[it.class] || it.enqueued.Before(oldest[it.class]) {
+			oldest[it.class] = it.enqueued
+			present[it.class] = true
+		}
+	}
+	class, best := priorityClass(-1), priorityClass(0)
+	for c := range numClasses {
+		if !present[c] {
+			continue
+		}
+		aged := c - priorityClass(now.Sub(oldest[c])/priorityAging)
+		if class < 0 || aged < best {
+			class, best = c, aged
+		}
+	}
+	if class < 0 {
+		return -1
+	}
+
+	idx := -1
+	for i, it := range s.items {
+		if it.class != class {
+			continue
+		}
+		if idx < 0 || it.tag < s.items[idx].tag || (it.tag == s.items[idx].tag && it.seq < s.items[idx].seq) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// Order returns the waiting requests in the order they would be dispatched at now
+func (s *scheduler) Order(now time.Time) []*pb.JudgeClientRequest {
+	return slices.Collect(s.order(now))
+}
+
+// Position returns the 1-based position of the request in Order, or 0 if not waiting
+func (s *scheduler) Position(req *pb.JudgeClientRequest, now time.Time) int {
+	pos := 0
+	for r := range s.order(now) {
+		pos++
+		if r == req {
+			return pos
+		}
+	}
+	return 0
+}
+
+// order yields the requests as repeated Pop at now without modifying the scheduler.
+// The tags within a class do not change while dispatching, so the classes are
+// sorted once by tag and by age, and merged by the aged priority of the oldest
+// remaining item of each class.
+func (s *scheduler) order(now time.Time) iter.Seq[*pb.JudgeClientRequest] {
+	return func(yield func(*pb.JudgeClientRequest) bool) {
+		for _, it := range s.requeued {
+			if !yield(it.req) {
+				return
+			}
+		}
+		var byTag, byAge [numClasses][]*queueItem
+		for _, it := range s.items {
+			byTag[it.class] = append(byTag[it.class], it)
+			byAge[it.class] = append(byAge[it.class], it)
+		}
+		for c := range numClasses {
+			slices.SortFunc(byTag[c], func(a, b *queueItem) int {
+				return cmp.Or(cmp.Compare(a.tag, b.tag), cmp.Compare(a.seq, b.seq))
+			})
+			slices.SortFunc(byAge[c], func(a, b *queueItem) int {
+				return cmp.Or(a.enqueued.Compare(b.enqueued), cmp.Compare(a.seq, b.seq))
+			})
+		}
+		var head, oldest [numClasses]int
+		dispatched := make(map[*queueItem]bool, len(s.items))
+		for range s.items {
+			class, best := priorityClass(-1), priorityClass(0)
+			for c := range numClasses {
+				if head[c] == len(byTag[c]) {
+					continue
+				}
+				for dispatched[byAge[c][oldest[c]]] {
+					oldest[c]++
+				}
+				aged := c - priorityClass(now.Sub(byAge[c][oldest[c]].enqueued)/priorityAging)
+				if class < 0 || aged < best {
+					class, best = c, aged
+				}
+			}
+			it := byTag[class][head[class]]
+			head[class]++
+			dispatched[it] = true
+			if !yield(it.req) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// job is a synthetic request pushed at base + at
+type job struct {
+	id    string
+	class priorityClass
+	owner string
+	at    time.Duration
+}
+
+var schedBase = time.Unix(1700000000, 0)
+
+func schedRequest(id string) *pb.JudgeClientRequest {
+	return pb.JudgeClientRequest_builder{Id: proto.String(id)}.Build()
+}
+
+// pushJobs pushes the jobs in order
+func pushJobs(s *scheduler, jobs []job) {
+	for _, j := range jobs {
+		s.Push(schedRequest(j.id), j.class, j.owner, schedBase.Add(j.at))
+	}
+}
+
+// popAll pops every request at now and returns their ids
+func popAll(s *scheduler, now time.Time) []string {
+	var rt []string
+	for req := s.Pop(now); req != nil; req = s.Pop(now) {
+		rt = append(rt, req.GetId())
+	}
+	return rt
+}
+
+func requestIDs(reqs []*pb.JudgeClientRequest) []string {
+	rt := make([]string, 0, len(reqs))
+	for _, r := range reqs {
+		rt = append(rt, r.GetId())
+	}
+	return rt
+}
+
+// ownerJobs returns n jobs of the owner with ids owner1..ownerN
+func ownerJobs(owner string, n int, class priorityClass, at time.Duration) []job {
+	rt := make([]job, 0, n)
+	for i := range n {
+		rt = append(rt, job{id: fmt.Sprintf("%s%d", owner, i+1), class: class, owner: owner, at: at})
+	}
+	return rt
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		jobs []job
+		at   time.Duration // pop time after base
+		want []string
+	}{
+		{
+			name: "priority classes",
+			jobs: []job{
+				{"bulk", classBulk, "a", 0},
+				{"rejudge", classRejudge, "a", 0},
+				{"contest", classContest, "a", 0},
+				{"interactive", classInteractive, "a", 0},
+			},
+			want: []string{"interactive", "contest", "rejudge", "bulk"},
+		},
+		{
+			name: "fifo within owner",
+			jobs: ownerJobs("a", 3, classContest, 0),
+			want: []string{"a1", "a2", "a3"},
+		},
+		{
+			name: "round robin owners",
+			jobs: slices.Concat(ownerJobs("a", 3, classContest, 0), ownerJobs("b", 3, classContest, 0)),
+			want: []string{"a1", "b1", "a2", "b2", "a3", "b3"},
+		},
+		{
+			name: "late owner is not behind the backlog",
+			jobs: slices.Concat(ownerJobs("a", 4, classContest, 0), ownerJobs("b", 1, classContest, time.Second)),
+			want: []string{"a1", "b1", "a2", "a3", "a4"},
+		},
+		{
+			name: "unknown class is bulk",
+			jobs: []job{
+				{"unknown", priorityClass(42), "a", 0},
+				{"rejudge", classRejudge, "a", 0},
+			},
+			want: []string{"rejudge", "unknown"},
+		},
+		{
+			name: "aged bulk over interactive",
+			jobs: []job{
+				{"bulk", classBulk, "a", 0},
+				{"interactive", classInteractive, "b", 4 * priorityAging},
+			},
+			at:   4 * priorityAging,
+			want: []string{"bulk", "interactive"},
+		},
+		{
+			name: "aging ties keep the higher class",
+			jobs: []job{
+				{"bulk", classBulk, "a", 0},
+				{"contest", classContest, "b", 2 * priorityAging},
+			},
+			at:   2 * priorityAging,
+			want: []string{"contest", "bulk"},
+		},
+		{
+			name: "not aged yet",
+			jobs: []job{
+				{"bulk", classBulk, "a", 0},
+				{"interactive", classInteractive, "b", 0},
+			},
+			at:   priorityAging - time.Second,
+			want: []string{"interactive", "bulk"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler()
+			pushJobs(s, tt.jobs)
+			if got := popAll(s, schedBase.Add(tt.at)); !slices.Equal(got, tt.want) {
+				t.Fatalf("pop order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerFairShare(t *testing.T) {
+	tests := []struct {
+		name   string
+		heavy  int // queued by the heavy owner first
+		others []string
+		light  int // queued by each other owner
+	}{
+		{"one light owner", 100, []string{"b"}, 3},
+		{"several light owners", 100, []string{"b", "c", "d"}, 2},
+		{"more light jobs", 100, []string{"b", "c"}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler()
+			jobs := ownerJobs("a", tt.heavy, classContest, 0)
+			for _, o := range tt.others {
+				jobs = append(jobs, ownerJobs(o, tt.light, classContest, time.Second)...)
+			}
+			pushJobs(s, jobs)
+
+			// every light job is dispatched within the first rounds, while
+			// the heavy owner gets one job per round
+			rounds := tt.light * (len(tt.others) + 1)
+			got := popAll(s, schedBase.Add(time.Second))
+			if len(got) != len(jobs) {
+				t.Fatalf("popped %d, want %d", len(got), len(jobs))
+			}
+			heavy := 0
+			for _, id := range got[:rounds] {
+				if id[0] == 'a' {
+					heavy++
+				}
+			}
+			if heavy != tt.light {
+				t.Fatalf("heavy owner got %d of the first %d, want %d", heavy, rounds, tt.light)
+			}
+			for i, id := range got[rounds:] {
+				if want := fmt.Sprintf("a%d", tt.light+i+1); id != want {
+					t.Fatalf("after the light jobs got %s, want %s", id, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulerFairShareArrival(t *testing.T) {
+	// the light owner arrives after half of the heavy backlog is dispatched, and
+	// still waits only one round instead of the remaining backlog
+	s := newScheduler()
+	pushJobs(s, ownerJobs("a", 100, classContest, 0))
+	now := schedBase.Add(time.Second)
+	for range 50 {
+		s.Pop(now)
+	}
+	pushJobs(s, []job{{"b1", classContest, "b", time.Second}})
+	got := popAll(s, now)
+	if i := slices.Index(got, "b1"); i > 1 {
+		t.Fatalf("late light job dispatched at %d of %v, want within the first round", i, got[:4])
+	}
+}
+
+func TestSchedulerRequeue(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []job
+		requeue []string
+		want    []string
+	}{
+		{
+			name:    "requeued first",
+			jobs:    []job{{"i1", classInteractive, "a", 0}, {"c1", classContest, "b", 0}},
+			requeue: []string{"r1"},
+			want:    []string{"r1", "i1", "c1"},
+		},
+		{
+			name:    "requeued in order",
+			jobs:    []job{{"i1", classInteractive, "a", 0}},
+			requeue: []string{"r1", "r2"},
+			want:    []string{"r1", "r2", "i1"},
+		},
+		{
+			name:    "requeued only",
+			requeue: []string{"r1"},
+			want:    []string{"r1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler()
+			pushJobs(s, tt.jobs)
+			for _, id := range tt.requeue {
+				s.Requeue(schedRequest(id))
+			}
+			if s.Len() != len(tt.want) {
+				t.Fatalf("Len() = %d, want %d", s.Len(), len(tt.want))
+			}
+			if got := requestIDs(s.Order(schedBase)); !slices.Equal(got, tt.want) {
+				t.Fatalf("Order() = %v, want %v", got, tt.want)
+			}
+			if got := popAll(s, schedBase); !slices.Equal(got, tt.want) {
+				t.Fatalf("pop order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerOrderMatchesPop(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    uint64
+		jobs    int
+		owners  int
+		span    time.Duration // push times spread
+		at      time.Duration // order and pop time
+		requeue bool          // also requeue and pop while pushing
+	}{
+		{"single owner", 1, 50, 1, time.Minute, time.Minute, false},
+		{"many owners", 2, 200, 10, time.Minute, time.Minute, false},
+		{"aging", 3, 200, 5, 10 * priorityAging, 10 * priorityAging, false},
+		{"out of order push times", 4, 100, 4, 5 * priorityAging, 3 * priorityAging, false},
+		{"with requeue and pops", 5, 256, 8, 5 * priorityAging, 6 * priorityAging, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(tt.seed, 0))
+			s := newScheduler()
+			for i := range tt.jobs {
+				at := schedBase.Add(time.Duration(r.Int64N(int64(tt.span))))
+				req := schedRequest(fmt.Sprint(i))
+				if tt.requeue && r.IntN(10) == 0 {
+					s.Requeue(req)
+					continue
+				}
+				s.Push(req, priorityClass(r.IntN(int(numClasses))), fmt.Sprint(r.IntN(tt.owners)), at)
+				if tt.requeue && r.IntN(4) == 0 {
+					s.Pop(at)
+				}
+			}
+
+			now := schedBase.Add(tt.at)
+			order := s.Order(now)
+			if len(order) != s.Len() {
+				t.Fatalf("Order() has %d, want %d", len(order), s.Len())
+			}
+			for i, req := range order {
+				if p := s.Position(req, now); p != i+1 {
+					t.Fatalf("Position(%s) = %d, want %d", req.GetId(), p, i+1)
+				}
+			}
+			if p := s.Position(schedRequest("missing"), now); p != 0 {
+				t.Fatalf("Position(missing) = %d, want 0", p)
+			}
+			if got, want := popAll(s, now), requestIDs(order); !slices.Equal(got, want) {
+				t.Fatalf("pop order = %v, want Order() %v", got, want)
+			}
+		})
+	}
+}
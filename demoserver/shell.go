@@ -0,0 +1,576 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	shellScrollback   = 64 << 10 // latest output replayed to late joiners
+	shellClientBuffer = 64
+
+	shellIdleWarning = time.Minute // before closing idle shells
+	shellIdleCheck   = 5 * time.Second
+)
+
+// Shell serves one client of a shell session. The first message attaches to a
+// running session by id, or starts a new one owned by the caller. The owner
+// receives a resume token to reattach, and the session ends if no owner is
+// attached for the grace period. Other clients attach read only, or with input
+// if the caller is the logged in owner, an admin, or the owner shared the session
+// with write. Anonymous owners are only known by the resume token, as the client
+// IP could be shared by others. A reattaching client gets the output after its offset if it is still in
+// the scrollback.
+func (s *demoServer) Shell(ss pb.DemoBackend_ShellServer) error {
+	ctx := ss.Context()
+	first, err := ss.Recv()
+	if err != nil {
+		return err
+	}
+
+	var (
+		sh      *shellSession
+		c       *shellClient
+		content []byte
+		offset  uint64
+	)
+	if at := first.GetAttach(); at.GetSessionId() != "" {
+		id := at.GetSessionId()
+		if sh = s.sessions.Get(id); sh == nil {
+			return status.Errorf(codes.NotFound, "shell session %q not found", id)
+		}
+		owner := at.GetToken() != ""
+		if owner && subtle.ConstantTimeCompare([]byte(at.GetToken()), []byte(sh.token)) != 1 {
+			return status.Errorf(codes.PermissionDenied, "invalid resume token for shell session %q", id)
+		}
+		write := owner || at.GetWrite()
+		if write && !owner && !sh.canWrite(ctx) {
+			return status.Errorf(codes.PermissionDenied, "shell session %q is not shared with write", id)
+		}
+		if c, content, offset, err = sh.attach(callerKey(ctx), write, owner, at.GetOffset()); err != nil {
+			return err
+		}
+	} else {
+		if sh, c, err = s.startShell(ctx, at.GetProfile()); err != nil {
+			return err
+		}
+	}
+	defer sh.detach(c)
+	if !first.HasAttach() {
+		sh.handleInput(ctx, c, first)
+	}
+
+	out := pb.ShellOutput_builder{
+		SessionId: &sh.id,
+		Write:     &c.write,
+		Content:   content,
+		Offset:    &offset,
+		RecordId:  proto.String(sh.rec.ID()),
+	}.Build()
+	if c.owner {
+		out.SetToken(sh.token)
+	}
+	if err := ss.Send(out); err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := ss.Recv()
+			s.logger.Debug("ss recv", zap.Any("message", msg))
+			if err != nil {
+				errCh <- err
+				return
+			}
+			sh.handleInput(ctx, c, msg)
+		}
+	}()
+
+	for {
+		select {
+		case <-errCh:
+			return nil
+
+		case msg, ok := <-c.out:
+			if !ok {
+				return nil
+			}
+			if err := ss.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startShell starts the profile in the exec server as a new session with the
+// caller attached as the owner
+func (s *demoServer) startShell(ctx context.Context, profile string) (*shellSession, *shellClient, error) {
+	sp, err := s.profiles.Get(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	release, err := s.shells.Acquire(ctx, "shells")
+	if err != nil {
+		return nil, nil, err
+	}
+	user := userFromContext(ctx)
+	copyIn := sp.CopyIn()
+	maps.Copy(copyIn, s.files.CopyIn(callerKey(ctx)))
+	files := []*execpb.Request_File{
+		execpb.Request_File_builder{StreamIn: &emptypb.Empty{}}.Build(),
+		execpb.Request_File_builder{StreamOut: &emptypb.Empty{}}.Build(),
+		execpb.Request_File_builder{StreamOut: &emptypb.Empty{}}.Build(),
+	}
+	var (
+		copyOut  []*execpb.Request_CmdCopyOutFile
+		transfer *shellTransfer
+	)
+	if !sp.NoWorkspace {
+		// fd 3 and 4 of the transfer helper
+		files = append(files,
+			execpb.Request_File_builder{StreamIn: &emptypb.Empty{}}.Build(),
+			execpb.Request_File_builder{StreamOut: &emptypb.Empty{}}.Build(),
+		)
+		transfer = newShellTransfer()
+		s.loadShellWorkspace(ctx, user.ID, copyIn)
+		copyOut = append(copyOut, execpb.Request_CmdCopyOutFile_builder{
+			Name:     shellArchive,
+			Optional: true,
+		}.Build())
+	}
+
+	// the session outlives the request when shared
+	sctx, cancel := context.WithCancel(context.Background())
+	sc, err := s.client.ExecStream(sctx)
+	if err != nil {
+		cancel()
+		release()
+		return nil, nil, err
+	}
+	err = sc.Send(execpb.StreamRequest_builder{
+		ExecRequest: execpb.Request_builder{
+			Cmd: []*execpb.Request_CmdType{execpb.Request_CmdType_builder{
+				Args:           sp.Command(),
+				Env:            sp.Env,
+				Files:          files,
+				Tty:            true,
+				CpuTimeLimit:   uint64(sp.CPULimit),
+				ClockTimeLimit: uint64(sp.ClockLimit),
+				MemoryLimit:    sp.MemoryLimit << 20,
+				ProcLimit:      sp.ProcLimit,
+				CopyIn:         copyIn,
+				CopyOutCached:  copyOut,
+				CopyOutMax:     s.files.archiveMax,
+			}.Build()},
+		}.Build(),
+	}.Build())
+	if err != nil {
+		cancel()
+		release()
+		return nil, nil, err
+	}
+	rec, err := newShellRecorder(ctx, s.db, s.logger, user, sp)
+	if err != nil {
+		cancel()
+		release()
+		return nil, nil, err
+	}
+
+	sh := &shellSession{
+		id:        newShellSessionID(),
+		token:     newShellSessionID(),
+		ownerKey:  callerKey(ctx),
+		grace:     s.shellGrace,
+		idle:      s.shellIdle,
+		user:      user,
+		profile:   sp.Name,
+		cpuLimit:  sp.CPULimit,
+		start:     time.Now(),
+		logger:    s.logger,
+		rec:       rec,
+		transfer:  transfer,
+		sc:        sc,
+		cancel:    cancel,
+		clients:   make(map[*shellClient]bool),
+		lastInput: time.Now(),
+	}
+	c, _, _, _ := sh.attach(sh.ownerKey, true, true, 0)
+	s.sessions.Add(sh)
+	go sh.idleLoop(sctx)
+	go func() {
+		exited, exit := sh.outputLoop()
+		s.sessions.Remove(sh.id)
+		release()
+		if exited != nil {
+			hash, size := s.saveShellArchive(exited, user.ID)
+			rec.SetArchive(hash)
+			s.saveShellWorkspace(user.ID, hash, size)
+		}
+		rec.Close(exit.GetStatus(), exit.GetExitStatus(), exit.GetTime(), exit.GetMemory())
+	}()
+	return sh, c, nil
+}
+
+func newShellSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// shellSessions is the registry of the running shells by session id
+type shellSessions struct {
+	mu sync.Mutex
+	m  map[string]*shellSession
+}
+
+func newShellSessions() *shellSessions {
+	return &shellSessions{m: make(map[string]*shellSession)}
+}
+
+func (r *shellSessions) Get(id string) *shellSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.m[id]
+}
+
+func (r *shellSessions) Add(sh *shellSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.m[sh.id] = sh
+}
+
+// List returns the running shells ordered by start time
+func (r *shellSessions) List() []*shellSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rt := slices.Collect(maps.Values(r.m))
+	slices.SortFunc(rt, func(a, b *shellSession) int {
+		return a.start.Compare(b.start)
+	})
+	return rt
+}
+
+func (r *shellSessions) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.m, id)
+}
+
+// shellSession is a running shell, its output is fanned out to the clients
+type shellSession struct {
+	id       string
+	token    string // resume token of the owner
+	ownerKey string // callerKey of the owner
+	grace    time.Duration
+	idle     time.Duration // without input before closed
+	user     userInfo      // started the session
+	profile  string
+	cpuLimit time.Duration
+	start    time.Time
+	logger   *zap.Logger
+	rec      *shellRecorder
+	transfer *shellTransfer // nil if the profile is not wrapped by the script
+	sc       execpb.Executor_ExecStreamClient
+	cancel   context.CancelFunc
+
+	sendMu sync.Mutex // sc.Send is not safe for concurrent use
+
+	mu         sync.Mutex
+	clients    map[*shellClient]bool
+	owners     int         // attached owner clients
+	graceTimer *time.Timer // ends the session once no owner is attached
+	scrollback []byte
+	written    uint64 // output bytes, the scrollback is the end of them
+	shareWrite bool
+	lastInput  time.Time
+	bytesIn    uint64
+	reason     string // exit status if closed or killed
+	finished   bool
+}
+
+// shellClient is an attached client, out is closed when the session ends or
+// the client is too slow
+type shellClient struct {
+	key   string // callerKey
+	write bool
+	owner bool
+	out   chan *pb.ShellOutput
+}
+
+// attach adds the client and returns the output to replay after the offset with
+// its offset, or the whole scrollback if the offset is no longer available
+func (sh *shellSession) attach(key string, write, owner bool, offset uint64) (*shellClient, []byte, uint64, error) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if sh.finished {
+		return nil, nil, 0, status.Errorf(codes.NotFound, "shell session %q finished", sh.id)
+	}
+	c := &shellClient{key: key, write: write, owner: owner, out: make(chan *pb.ShellOutput, shellClientBuffer)}
+	sh.clients[c] = true
+	if owner {
+		sh.owners++
+		if sh.graceTimer != nil {
+			sh.graceTimer.Stop()
+			sh.graceTimer = nil
+		}
+	}
+	start := sh.written - uint64(len(sh.scrollback))
+	if offset < start || offset > sh.written {
+		offset = start
+	}
+	return c, bytes.Clone(sh.scrollback[offset-start:]), offset, nil
+}
+
+// detach removes the client, and starts the grace period if it is the last owner
+func (sh *shellSession) detach(c *shellClient) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	delete(sh.clients, c)
+	if !c.owner {
+		return
+	}
+	if sh.owners--; sh.owners > 0 || sh.finished {
+		return
+	}
+	sh.graceTimer = time.AfterFunc(sh.grace, func() {
+		sh.mu.Lock()
+		expired := sh.owners == 0
+		sh.mu.Unlock()
+		if expired {
+			sh.logger.Debug("shell grace period expired", zap.String("session", sh.id))
+			sh.stop("Disconnected")
+		}
+	})
+}
+
+// canWrite checks if the caller could attach with input without the resume token
+func (sh *shellSession) canWrite(ctx context.Context) bool {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.shareWrite || isAdmin(ctx) || (sh.user.ID != "" && sh.user.ID == userFromContext(ctx).ID)
+}
+
+func (sh *shellSession) handleInput(ctx context.Context, c *shellClient, msg *pb.ShellInput) {
+	switch msg.WhichRequest() {
+	case pb.ShellInput_Input_case:
+		if !c.write {
+			return
+		}
+		sh.mu.Lock()
+		sh.lastInput = time.Now()
+		sh.bytesIn += uint64(len(msg.GetInput().GetContent()))
+		sh.mu.Unlock()
+		sh.rec.Input(msg.GetInput().GetContent())
+		sh.send(execpb.StreamRequest_builder{ExecInput: execpb.StreamRequest_Input_builder{
+			Content: msg.GetInput().GetContent(),
+		}.Build()}.Build())
+
+	case pb.ShellInput_Resize_case:
+		if !c.write {
+			return
+		}
+		sh.rec.Resize(msg.GetResize().GetCols(), msg.GetResize().GetRows())
+		sh.send(execpb.StreamRequest_builder{ExecResize: execpb.StreamRequest_Resize_builder{
+			Rows: msg.GetResize().GetRows(),
+			Cols: msg.GetResize().GetCols(),
+			X:    msg.GetResize().GetX(),
+			Y:    msg.GetResize().GetY(),
+		}.Build()}.Build())
+
+	case pb.ShellInput_Transfer_case:
+		req := msg.GetTransfer()
+		if !c.write {
+			sh.reply(c, pb.ShellOutput_builder{Transfer: pb.ShellTransfer_builder{
+				Name:     proto.String(req.GetName()),
+				Download: proto.Bool(req.GetDownload()),
+				Error:    proto.String("read only"),
+			}.Build()}.Build())
+			return
+		}
+		sh.mu.Lock()
+		sh.lastInput = time.Now()
+		sh.mu.Unlock()
+		go func() {
+			sh.reply(c, pb.ShellOutput_builder{Transfer: sh.transferFile(ctx, req)}.Build())
+		}()
+
+	case pb.ShellInput_Share_case:
+		sh.mu.Lock()
+		if c.owner || isAdmin(ctx) {
+			sh.shareWrite = msg.GetShare().GetWrite()
+		}
+		sh.mu.Unlock()
+	}
+}
+
+func (sh *shellSession) send(req *execpb.StreamRequest) {
+	sh.sendMu.Lock()
+	defer sh.sendMu.Unlock()
+	if err := sh.sc.Send(req); err != nil {
+		sh.logger.Debug("shell send", zap.String("session", sh.id), zap.Error(err))
+		sh.cancel()
+	}
+}
+
+// outputLoop fans out the output until the shell exits or the session is
+// canceled. It returns the exec response if exited, and the exit message sent
+// to the clients.
+func (sh *shellSession) outputLoop() (exited *execpb.Response, exit *pb.ShellExit) {
+	defer func() {
+		sh.cancel()
+		sh.mu.Lock()
+		if exited != nil {
+			exit = shellExit(exited)
+			if sh.reason != "" {
+				exit.SetStatus(sh.reason)
+			}
+		} else {
+			exit = pb.ShellExit_builder{Status: proto.String(cmp.Or(sh.reason, "Disconnected"))}.Build()
+		}
+		sh.fanout(pb.ShellOutput_builder{Exit: exit}.Build())
+		sh.finished = true
+		for c := range sh.clients {
+			delete(sh.clients, c)
+			close(c.out)
+		}
+		sh.mu.Unlock()
+	}()
+
+	for {
+		msg, err := sh.sc.Recv()
+		sh.logger.Debug("sc recv", zap.Any("message", msg))
+		if err != nil {
+			return nil, nil
+		}
+		switch msg.WhichResponse() {
+		case execpb.StreamResponse_ExecOutput_case:
+			content := msg.GetExecOutput().GetContent()
+			if msg.GetExecOutput().GetFd() == shellTransferOut && sh.transfer != nil {
+				sh.transfer.Write(content)
+				continue
+			}
+			sh.rec.Output(content)
+			sh.broadcast(content)
+
+		case execpb.StreamResponse_ExecResponse_case:
+			return msg.GetExecResponse(), nil
+		}
+	}
+}
+
+// idleLoop warns and then closes the session once no input for the idle timeout
+func (sh *shellSession) idleLoop(ctx context.Context) {
+	if sh.idle <= 0 {
+		return
+	}
+	warnAt := sh.idle - min(shellIdleWarning, sh.idle/2)
+	warned := false
+
+	ticker := time.NewTicker(min(shellIdleCheck, warnAt))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		sh.mu.Lock()
+		idle := time.Since(sh.lastInput)
+		switch {
+		case idle >= sh.idle:
+			sh.mu.Unlock()
+			sh.stop("Idle Timeout")
+			return
+
+		case idle >= warnAt && !warned:
+			warned = true
+			sh.fanout(pb.ShellOutput_builder{Notice: proto.String(fmt.Sprintf(
+				"idle for %v, the shell will be closed in %v without input",
+				idle.Round(time.Second), (sh.idle - idle).Round(time.Second),
+			))}.Build())
+
+		case idle < warnAt:
+			warned = false
+		}
+		sh.mu.Unlock()
+	}
+}
+
+// broadcast sends the output to every client and keeps it in the scrollback
+func (sh *shellSession) broadcast(content []byte) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	sh.written += uint64(len(content))
+	sh.scrollback = append(sh.scrollback, content...)
+	if n := len(sh.scrollback) - shellScrollback; n > 0 {
+		sh.scrollback = append(sh.scrollback[:0], sh.scrollback[n:]...)
+	}
+	sh.fanout(pb.ShellOutput_builder{Content: content}.Build())
+}
+
+// fanout sends the message to every client, the slow ones are detached. It is
+// called with mu held.
+func (sh *shellSession) fanout(msg *pb.ShellOutput) {
+	for c := range sh.clients {
+		sh.sendClient(c, msg)
+	}
+}
+
+// reply sends the message to the client if it is still attached
+func (sh *shellSession) reply(c *shellClient, msg *pb.ShellOutput) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.clients[c] {
+		sh.sendClient(c, msg)
+	}
+}
+
+// sendClient sends the message or detaches the slow client, called with mu held
+func (sh *shellSession) sendClient(c *shellClient, msg *pb.ShellOutput) {
+	select {
+	case c.out <- msg:
+	default:
+		delete(sh.clients, c)
+		close(c.out)
+	}
+}
+
+// shellExit converts the exec response into the exit message
+func shellExit(r *execpb.Response) *pb.ShellExit {
+	if r.GetError() != "" || len(r.GetResults()) == 0 {
+		return pb.ShellExit_builder{Status: proto.String(r.GetError())}.Build()
+	}
+	rt := r.GetResults()[0]
+	status := rt.GetStatus().String()
+	if rt.GetError() != "" {
+		status += ": " + rt.GetError()
+	}
+	return pb.ShellExit_builder{
+		Status:     &status,
+		ExitStatus: proto.Int32(rt.GetExitStatus()),
+		Time:       proto.Uint64(rt.GetTime() / uint64(time.Millisecond)),
+		Memory:     proto.Uint64(rt.GetMemory() >> 10),
+	}.Build()
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// The exec server only copies files into the sandbox when the shell starts and out
+// when it exits, so the uploaded files and the saved workspace are copied in to
+// the new shells, and the working directory is archived after the interactive
+// shell exits to be copied out. The uploaded files are kept over the ones in the
+// workspace. The script also runs the transfer helper in the background for the
+// files moved while the shell runs. The profile command is passed as the script
+// arguments.
+const (
+	shellArchive = ".workspace.tar"
+	shellScript  = "tar -xkf " + shellArchive + " 2>/dev/null; rm -f " + shellArchive + "; " +
+		"{ " + shellTransferHelper + " } 2>/dev/null & t=$!; " +
+		"\"$@\" 3<&- 4>&-; s=$?; kill $t 2>/dev/null; " +
+		"tar -cf " + shellArchive + " --exclude=./" + shellArchive + " --exclude=./" + shellTransferTemp +
+		" . 2>/dev/null; exit $s"
+
+	maxShellFiles = 64 // uploaded files per caller
+)
+
+type shellFile struct {
+	fileID string // in the exec server file store
+	size   uint64
+}
+
+// shellFiles are the files uploaded by each caller
+type shellFiles struct {
+	max          uint64 // total size per caller
+	archiveMax   uint64
+	workspaceMax int // archive size kept as the workspace
+
+	mu sync.Mutex
+	m  map[string]map[string]shellFile // callerKey -> name
+}
+
+func newShellFiles(max, archiveMax uint64, workspaceMax int) *shellFiles {
+	return &shellFiles{
+		max:          max,
+		archiveMax:   archiveMax,
+		workspaceMax: workspaceMax,
+		m:            make(map[string]map[string]shellFile),
+	}
+}
+
+// Add stores the file unless the caller is over the limit, and returns the
+// replaced file to delete
+func (f *shellFiles) Add(key, name string, file shellFile) (shellFile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	files := f.m[key]
+	old, replace := files[name]
+	total := file.size
+	for n, sf := range files {
+		if n != name {
+			total += sf.size
+		}
+	}
+	if total > f.max {
+		return shellFile{}, status.Errorf(codes.ResourceExhausted, "uploaded files exceed %d bytes", f.max)
+	}
+	if !replace && len(files) >= maxShellFiles {
+		return shellFile{}, status.Errorf(codes.ResourceExhausted, "uploaded files exceed %d files", maxShellFiles)
+	}
+	if files == nil {
+		files = make(map[string]shellFile)
+		f.m[key] = files
+	}
+	files[name] = file
+	return old, nil
+}
+
+// Remove removes the file and returns it to delete
+func (f *shellFiles) Remove(key, name string) (shellFile, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, ok := f.m[key][name]
+	delete(f.m[key], name)
+	if len(f.m[key]) == 0 {
+		delete(f.m, key)
+	}
+	return file, ok
+}
+
+// List returns the files of the caller ordered by name without content
+func (f *shellFiles) List(key string) []*pb.ShellFile {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rt := make([]*pb.ShellFile, 0, len(f.m[key]))
+	for name, file := range f.m[key] {
+		rt = append(rt, pb.ShellFile_builder{
+			Name: proto.String(name),
+			Size: proto.Uint64(file.size),
+		}.Build())
+	}
+	slices.SortFunc(rt, func(a, b *pb.ShellFile) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return rt
+}
+
+// CopyIn returns the files of the caller as exec copy in
+func (f *shellFiles) CopyIn(key string) map[string]*execpb.Request_File {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rt := make(map[string]*execpb.Request_File, len(f.m[key]))
+	for name, file := range f.m[key] {
+		rt[name] = execpb.Request_File_builder{
+			Cached: execpb.Request_CachedFile_builder{FileID: file.fileID}.Build(),
+		}.Build()
+	}
+	return rt
+}
+
+// cleanShellFileName checks the name is a file inside the working directory
+func cleanShellFileName(name string) (string, error) {
+	cleaned := path.Clean(name)
+	if name == "" || path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." ||
+		strings.HasPrefix(cleaned, "../") || strings.ContainsAny(cleaned, "\n\x00") ||
+		cleaned == shellArchive || cleaned == shellTransferTemp {
+		return "", status.Errorf(codes.InvalidArgument, "invalid file name %q", name)
+	}
+	return cleaned, nil
+}
+
+// UploadShellFile stores the file in the exec server file store for the new
+// shells of the caller, the file of the same name is replaced
+func (s *demoServer) UploadShellFile(ctx context.Context, req *pb.ShellFile) (*pb.ListShellFilesResponse, error) {
+	name, err := cleanShellFileName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(req.GetContent())) > s.files.max {
+		return nil, status.Errorf(codes.ResourceExhausted, "file exceeds %d bytes", s.files.max)
+	}
+	id, err := s.client.FileAdd(ctx, execpb.FileContent_builder{
+		Name:    path.Base(name),
+		Content: req.GetContent(),
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	key := callerKey(ctx)
+	old, err := s.files.Add(key, name, shellFile{fileID: id.GetFileID(), size: uint64(len(req.GetContent()))})
+	if err != nil {
+		s.deleteExecFile(id.GetFileID())
+		return nil, err
+	}
+	if old.fileID != "" {
+		s.deleteExecFile(old.fileID)
+	}
+	return pb.ListShellFilesResponse_builder{Files: s.files.List(key)}.Build(), nil
+}
+
+func (s *demoServer) ListShellFiles(ctx context.Context, req *pb.ListShellFilesRequest) (*pb.ListShellFilesResponse, error) {
+	return pb.ListShellFilesResponse_builder{Files: s.files.List(callerKey(ctx))}.Build(), nil
+}
+
+func (s *demoServer) DeleteShellFile(ctx context.Context, req *pb.DeleteShellFileRequest) (*pb.ListShellFilesResponse, error) {
+	name, err := cleanShellFileName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	key := callerKey(ctx)
+	file, ok := s.files.Remove(key, name)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "file %q not found", name)
+	}
+	s.deleteExecFile(file.fileID)
+	return pb.ListShellFilesResponse_builder{Files: s.files.List(key)}.Build(), nil
+}
+
+func (s *demoServer) deleteExecFile(id string) {
+	_, err := s.client.FileDelete(context.TODO(), execpb.FileID_builder{FileID: id}.Build())
+	if err != nil {
+		s.logger.Warn("delete exec file", zap.String("fileId", id), zap.Error(err))
+	}
+}
+
+// saveShellArchive moves the working directory archive from the exec server file
+// store to the blob store and returns its hash and size, or empty if not archived.
+// The archives of anonymous shells are dropped.
+func (s *demoServer) saveShellArchive(r *execpb.Response, userID string) (string, int) {
+	if len(r.GetResults()) == 0 {
+		return "", 0
+	}
+	id := r.GetResults()[0].GetFileIDs()[shellArchive]
+	if id == "" {
+		return "", 0
+	}
+	defer s.deleteExecFile(id)
+	if userID == "" {
+		return "", 0
+	}
+
+	fc, err := s.client.FileGet(context.TODO(), execpb.FileID_builder{FileID: id}.Build())
+	if err != nil {
+		s.logger.Warn("get shell archive", zap.Error(err))
+		return "", 0
+	}
+	hash, err := s.blob.Put(context.TODO(), fc.GetContent())
+	if err != nil {
+		s.logger.Warn("store shell archive", zap.Error(err))
+		return "", 0
+	}
+	return hash, len(fc.GetContent())
+}
+
+// GetShellArchive streams the tar archive of the working directory after the
+// shell exited, to the user started it or admins
+func (s *demoServer) GetShellArchive(req *pb.GetShellArchiveRequest, gs pb.DemoBackend_GetShellArchiveServer) error {
+	ctx := gs.Context()
+	if _, err := bson.ObjectIDFromHex(req.GetId()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid session id %q", req.GetId())
+	}
+	ss, err := s.db.GetShellSession(ctx, req.GetId())
+	if errors.Is(err, mongo.ErrNoDocuments) || (err == nil && !ownsShellSession(ctx, ss)) {
+		return status.Errorf(codes.NotFound, "shell session %q not found", req.GetId())
+	}
+	if err != nil {
+		return err
+	}
+	if ss.Archive == "" {
+		return status.Errorf(codes.NotFound, "shell session %q has no archive", req.GetId())
+	}
+	return s.sendBlob(ctx, ss.Archive, gs.Send)
+}
+
+// ownsShellSession checks if the caller started the session, by the user, or by
+// the client ip for anonymous sessions
+func ownsShellSession(ctx context.Context, ss *ShellSession) bool {
+	if isAdmin(ctx) {
+		return true
+	}
+	u := userFromContext(ctx)
+	if ss.UserID != "" {
+		return u.ID == ss.UserID
+	}
+	return ss.RemoteAddr != "" && u.ID == "" && u.IP == ss.RemoteAddr
+}
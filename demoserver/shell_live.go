@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// shellStopTimeout is the time for the script to archive the working directory
+	// after the hangup before the shell is killed
+	shellStopTimeout = 10 * time.Second
+	// shellKillTimeout is the time for the exec server to respond the canceled
+	// shell before the stream is closed
+	shellKillTimeout = 5 * time.Second
+)
+
+func (s *demoServer) ListLiveShells(ctx context.Context, req *pb.ListLiveShellsRequest) (*pb.ListLiveShellsResponse, error) {
+	if err := checkShellAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var rt []*pb.LiveShell
+	for _, sh := range s.sessions.List() {
+		if req.GetUserId() != "" && req.GetUserId() != sh.user.ID {
+			continue
+		}
+		rt = append(rt, sh.liveShell())
+	}
+	return pb.ListLiveShellsResponse_builder{Shells: rt}.Build(), nil
+}
+
+// KillShell stops the shell, the clients get the exit message with status Killed
+func (s *demoServer) KillShell(ctx context.Context, req *pb.KillShellRequest) (*pb.LiveShell, error) {
+	if err := checkShellAdmin(ctx); err != nil {
+		return nil, err
+	}
+	sh := s.sessions.Get(req.GetSessionId())
+	if sh == nil {
+		return nil, status.Errorf(codes.NotFound, "shell session %q not found", req.GetSessionId())
+	}
+	s.logger.Info("kill shell", zap.String("session", sh.id), zap.String("by", callerKey(ctx)))
+	sh.stop("Killed")
+	return sh.liveShell(), nil
+}
+
+// stop hangs up the shell so that the working directory is archived as when it
+// exits, and kills it if it does not exit in time, e.g. the hangup is ignored
+func (sh *shellSession) stop(reason string) {
+	if sh.transfer == nil {
+		sh.kill(reason)
+		return
+	}
+	sh.mu.Lock()
+	sh.reason = reason
+	sh.mu.Unlock()
+
+	time.AfterFunc(shellStopTimeout, func() { sh.kill(reason) })
+	go sh.hangup()
+}
+
+// kill asks the exec server to cancel the shell so that the exit is recorded, and
+// closes the stream if it does not respond in time
+func (sh *shellSession) kill(reason string) {
+	sh.mu.Lock()
+	sh.reason = reason
+	sh.mu.Unlock()
+
+	sh.send(execpb.StreamRequest_builder{ExecCancel: &emptypb.Empty{}}.Build())
+	time.AfterFunc(shellKillTimeout, sh.cancel)
+}
+
+func (sh *shellSession) liveShell() *pb.LiveShell {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	return pb.LiveShell_builder{
+		SessionId:  &sh.id,
+		RecordId:   proto.String(sh.rec.ID()),
+		UserId:     &sh.user.ID,
+		UserName:   &sh.user.Name,
+		RemoteAddr: &sh.user.IP,
+		Profile:    &sh.profile,
+		Start:      timestamppb.New(sh.start),
+		LastInput:  timestamppb.New(sh.lastInput),
+		CpuLimit:   proto.Uint64(uint64(sh.cpuLimit.Milliseconds())),
+		BytesIn:    proto.Uint64(sh.bytesIn),
+		BytesOut:   proto.Uint64(sh.written),
+		Clients:    proto.Uint32(uint32(len(sh.clients))),
+	}.Build()
+}
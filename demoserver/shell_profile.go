@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"github.com/goccy/go-yaml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// shellProfile is the command and limits to start a shell with, zero limits
+// are the ones of the default bash profile
+type shellProfile struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Args        []string          `yaml:"args"`
+	Env         []string          `yaml:"env"`
+	CPULimit    time.Duration     `yaml:"cpuLimit"`
+	ClockLimit  time.Duration     `yaml:"clockLimit"`
+	MemoryLimit uint64            `yaml:"memoryLimit"` // MiB
+	ProcLimit   uint64            `yaml:"procLimit"`
+	Files       map[string]string `yaml:"files"` // initial files in the working directory
+	NoWorkspace bool              `yaml:"noWorkspace"`
+}
+
+// shellProfileConfig is the SHELL_PROFILES content
+type shellProfileConfig struct {
+	Profiles []shellProfile `yaml:"profiles"`
+}
+
+var defaultShellProfile = shellProfile{
+	Name:        "bash",
+	Description: "Bash",
+	Args:        []string{"/bin/bash", "-i"},
+	Env:         []string{"PATH=/usr/local/bin:/usr/bin:/bin", "HOME=/w", "TERM=xterm-256color"},
+	CPULimit:    30 * time.Second,
+	ClockLimit:  30 * time.Minute,
+	MemoryLimit: 256,
+	ProcLimit:   50,
+}
+
+// shellProfiles are the configured profiles in order, the first is the default
+type shellProfiles struct {
+	list []*shellProfile
+	m    map[string]*shellProfile
+}
+
+// loadShellProfiles reads the profile file, or returns the default bash profile
+// if path is empty
+func loadShellProfiles(path string) (*shellProfiles, error) {
+	c := shellProfileConfig{Profiles: []shellProfile{defaultShellProfile}}
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c.Profiles = nil
+		if err := yaml.Unmarshal(b, &c); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(c.Profiles) == 0 {
+			return nil, fmt.Errorf("%s: no profiles", path)
+		}
+	}
+
+	rt := &shellProfiles{m: make(map[string]*shellProfile)}
+	for i := range c.Profiles {
+		p := &c.Profiles[i]
+		if p.Name == "" || len(p.Args) == 0 {
+			return nil, fmt.Errorf("%s: profile %d requires name and args", path, i)
+		}
+		if _, ok := rt.m[p.Name]; ok {
+			return nil, fmt.Errorf("%s: duplicated profile %q", path, p.Name)
+		}
+		files := make(map[string]string, len(p.Files))
+		for name, content := range p.Files {
+			cleaned, err := cleanShellFileName(name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: profile %q: %w", path, p.Name, err)
+			}
+			files[cleaned] = content
+		}
+		p.Files = files
+		if p.Env == nil {
+			p.Env = defaultShellProfile.Env
+		}
+		if p.CPULimit == 0 {
+			p.CPULimit = defaultShellProfile.CPULimit
+		}
+		if p.ClockLimit == 0 {
+			p.ClockLimit = defaultShellProfile.ClockLimit
+		}
+		if p.MemoryLimit == 0 {
+			p.MemoryLimit = defaultShellProfile.MemoryLimit
+		}
+		if p.ProcLimit == 0 {
+			p.ProcLimit = defaultShellProfile.ProcLimit
+		}
+		rt.list = append(rt.list, p)
+		rt.m[p.Name] = p
+	}
+	return rt, nil
+}
+
+// Get returns the profile by name, or the default one if name is empty
+func (p *shellProfiles) Get(name string) (*shellProfile, error) {
+	if name == "" {
+		return p.list[0], nil
+	}
+	if sp, ok := p.m[name]; ok {
+		return sp, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown shell profile %q", name)
+}
+
+// Command returns the command, wrapped to restore and archive the workspace unless
+// disabled
+func (sp *shellProfile) Command() []string {
+	if sp.NoWorkspace {
+		return sp.Args
+	}
+	return append([]string{"/bin/bash", "-c", shellScript, sp.Name}, sp.Args...)
+}
+
+// Term returns the TERM in the profile environment
+func (sp *shellProfile) Term() string {
+	for _, e := range sp.Env {
+		if v, ok := strings.CutPrefix(e, "TERM="); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// CopyIn returns the initial files as exec copy in
+func (sp *shellProfile) CopyIn() map[string]*execpb.Request_File {
+	rt := make(map[string]*execpb.Request_File, len(sp.Files))
+	for name, content := range sp.Files {
+		rt[name] = execpb.Request_File_builder{
+			Memory: execpb.Request_MemoryFile_builder{Content: []byte(content)}.Build(),
+		}.Build()
+	}
+	return rt
+}
+
+func (s *demoServer) ListShellProfiles(ctx context.Context, req *pb.ListShellProfilesRequest) (*pb.ListShellProfilesResponse, error) {
+	rt := make([]*pb.ShellProfile, 0, len(s.profiles.list))
+	for _, p := range s.profiles.list {
+		rt = append(rt, pb.ShellProfile_builder{
+			Name:        &p.Name,
+			Description: &p.Description,
+		}.Build())
+	}
+	return pb.ListShellProfilesResponse_builder{Profiles: rt}.Build(), nil
+}
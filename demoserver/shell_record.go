@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+const (
+	shellChunkEvents  = 256       // events per ShellEvents chunk
+	shellChunkSize    = 256 << 10 // bytes per ShellEvents chunk
+	shellFlushPeriod  = 5 * time.Second
+	maxShellRecording = 64 << 20 // recorded bytes per session, the rest is dropped
+
+	defaultShellWidth  = 80
+	defaultShellHeight = 24
+)
+
+// shellRecorder records the shell events in asciicast v2 order and flushes them
+// in chunks in background so that the shell is not blocked by the database
+type shellRecorder struct {
+	db      *db
+	logger  *zap.Logger
+	session *ShellSession
+	start   time.Time
+
+	mu      sync.Mutex
+	events  []ShellEvent
+	size    int // of events
+	total   int // recorded bytes
+	resized bool
+	closed  bool
+	carry   map[string][]byte // incomplete utf-8 sequence at the end of "o" / "i"
+
+	full chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newShellRecorder stores the session metadata and starts the flush loop
+func newShellRecorder(ctx context.Context, db *db, logger *zap.Logger, user userInfo, sp *shellProfile) (*shellRecorder, error) {
+	start := time.Now()
+	ss, err := db.AddShellSession(ctx, &ShellSession{
+		UserID:     user.ID,
+		UserName:   user.Name,
+		RemoteAddr: user.IP,
+		Profile:    sp.Name,
+		Command:    sp.Args,
+		Term:       sp.Term(),
+		Start:      &start,
+		Width:      defaultShellWidth,
+		Height:     defaultShellHeight,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &shellRecorder{
+		db:      db,
+		logger:  logger,
+		session: ss,
+		start:   start,
+		carry:   make(map[string][]byte),
+		full:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	r.wg.Add(1)
+	go r.loop()
+	return r, nil
+}
+
+// Output records terminal output
+func (r *shellRecorder) Output(b []byte) {
+	r.record("o", b)
+}
+
+// Input records terminal input
+func (r *shellRecorder) Input(b []byte) {
+	r.record("i", b)
+}
+
+// Resize records terminal size, the first one before any output becomes the
+// initial size of the recording
+func (r *shellRecorder) Resize(cols, rows uint32) {
+	r.mu.Lock()
+	if !r.closed && !r.resized && r.session.Events == 0 && len(r.events) == 0 {
+		r.resized = true
+		r.session.Width, r.session.Height = cols, rows
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+	r.record("r", fmt.Appendf(nil, "%dx%d", cols, rows))
+}
+
+func (r *shellRecorder) record(typ string, b []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+	if r.total+len(b) > maxShellRecording {
+		r.session.Truncated = true
+		return
+	}
+	r.total += len(b)
+
+	// keep the incomplete utf-8 sequence for the next event of the same type
+	if typ != "r" {
+		b = append(r.carry[typ], b...)
+		n := validUTF8Prefix(b)
+		r.carry[typ] = append([]byte(nil), b[n:]...)
+		b = b[:n]
+		if len(b) == 0 {
+			return
+		}
+	}
+	r.events = append(r.events, ShellEvent{
+		Time: time.Since(r.start).Seconds(),
+		Type: typ,
+		Data: string(b),
+	})
+	r.size += len(b)
+	if len(r.events) >= shellChunkEvents || r.size >= shellChunkSize {
+		select {
+		case r.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// validUTF8Prefix returns the length without the incomplete utf-8 sequence at
+// the end, invalid bytes are kept and replaced on storing
+func validUTF8Prefix(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(b[i]) {
+			continue
+		}
+		if !utf8.FullRune(b[i:]) {
+			return i
+		}
+		break
+	}
+	return len(b)
+}
+
+func (r *shellRecorder) loop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(shellFlushPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		case <-r.full:
+		}
+		r.flush()
+	}
+}
+
+func (r *shellRecorder) flush() {
+	r.mu.Lock()
+	events := r.events
+	r.events, r.size = nil, 0
+	seq := r.session.Chunks
+	if len(events) > 0 {
+		r.session.Chunks++
+		r.session.Events += len(events)
+	}
+	r.mu.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	for i := range events {
+		events[i].Data = toValidUTF8(events[i].Data)
+	}
+	err := r.db.AddShellEvents(context.TODO(), &ShellEvents{
+		SessionID: *r.session.ID,
+		Seq:       seq,
+		Events:    events,
+	})
+	if err != nil {
+		r.logger.Warn("store shell events", zap.Stringer("session", r.session.ID), zap.Error(err))
+	}
+}
+
+// SetArchive sets the blob hash of the working directory archive, stored on Close
+func (r *shellRecorder) SetArchive(hash string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.session.Archive = hash
+}
+
+// ID returns the recorded session id
+func (r *shellRecorder) ID() string {
+	return r.session.ID.Hex()
+}
+
+// Close flushes the remaining events and stores the exit status
+func (r *shellRecorder) Close(status string, exitStatus int32, timeMs, memoryKb uint64) {
+	close(r.done)
+	r.wg.Wait()
+
+	r.mu.Lock()
+	for typ, b := range r.carry {
+		if len(b) > 0 {
+			r.events = append(r.events, ShellEvent{Time: time.Since(r.start).Seconds(), Type: typ, Data: string(b)})
+		}
+	}
+	r.closed = true
+	r.mu.Unlock()
+	r.flush()
+
+	end := time.Now()
+	r.session.End = &end
+	r.session.Status = status
+	r.session.ExitStatus = exitStatus
+	r.session.Time = timeMs
+	r.session.Memory = memoryKb
+	if err := r.db.FinishShellSession(context.TODO(), r.session); err != nil {
+		r.logger.Warn("finish shell session", zap.Stringer("session", r.session.ID), zap.Error(err))
+	}
+}
+
+func toValidUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
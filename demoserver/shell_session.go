@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recorded shell sessions are only visible to admins
+func checkShellAdmin(ctx context.Context) error {
+	if !isAdmin(ctx) {
+		return status.Errorf(codes.PermissionDenied, "shell sessions require admin role")
+	}
+	return nil
+}
+
+func (s *demoServer) ListShellSessions(ctx context.Context, req *pb.ListShellSessionsRequest) (*pb.ListShellSessionsResponse, error) {
+	if err := checkShellAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetId() != "" {
+		if _, err := bson.ObjectIDFromHex(req.GetId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid session id %q", req.GetId())
+		}
+	}
+	ss, err := s.db.QueryShellSessions(ctx, req.GetId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	rt := make([]*pb.ShellSession, 0, len(ss))
+	for i := range ss {
+		rt = append(rt, convertShellSession(&ss[i]))
+	}
+	return pb.ListShellSessionsResponse_builder{Sessions: rt}.Build(), nil
+}
+
+// GetShellSession streams the metadata followed by the events, one chunk each
+func (s *demoServer) GetShellSession(req *pb.GetShellSessionRequest, gs pb.DemoBackend_GetShellSessionServer) error {
+	ctx := gs.Context()
+	if err := checkShellAdmin(ctx); err != nil {
+		return err
+	}
+	if _, err := bson.ObjectIDFromHex(req.GetId()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid session id %q", req.GetId())
+	}
+	ss, err := s.db.GetShellSession(ctx, req.GetId())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return status.Errorf(codes.NotFound, "shell session %q not found", req.GetId())
+	}
+	if err != nil {
+		return err
+	}
+	if err := gs.Send(pb.ShellSessionChunk_builder{Session: convertShellSession(ss)}.Build()); err != nil {
+		return err
+	}
+	return s.db.EachShellEvents(ctx, *ss.ID, func(se *ShellEvents) error {
+		events := make([]*pb.ShellEvent, 0, len(se.Events))
+		for _, e := range se.Events {
+			events = append(events, pb.ShellEvent_builder{
+				Time: &e.Time,
+				Type: &e.Type,
+				Data: &e.Data,
+			}.Build())
+		}
+		return gs.Send(pb.ShellSessionChunk_builder{Events: events}.Build())
+	})
+}
+
+func convertShellSession(ss *ShellSession) *pb.ShellSession {
+	id := ss.ID.Hex()
+	events := uint64(ss.Events)
+	rt := pb.ShellSession_builder{
+		Id:         &id,
+		UserId:     &ss.UserID,
+		UserName:   &ss.UserName,
+		RemoteAddr: &ss.RemoteAddr,
+		Width:      &ss.Width,
+		Height:     &ss.Height,
+		Status:     &ss.Status,
+		ExitStatus: &ss.ExitStatus,
+		Time:       &ss.Time,
+		Memory:     &ss.Memory,
+		Events:     &events,
+		Truncated:  &ss.Truncated,
+		Archive:    &ss.Archive,
+		Profile:    &ss.Profile,
+		Command:    ss.Command,
+		Term:       &ss.Term,
+	}.Build()
+	if ss.Start != nil {
+		rt.SetStart(timestamppb.New(*ss.Start))
+	}
+	if ss.End != nil {
+		rt.SetEnd(timestamppb.New(*ss.End))
+	}
+	return rt
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Files are moved in and out of the running shell by the helper started with the
+// workspace script. It reads the requests from fd 3, `put <size> <name>` followed
+// by the content or `get <max size> <name>`, and replies on fd 4 with `ok <size>`
+// followed by the content, or `err <size>` where the size is set if the file is
+// too large. The content goes through a temporary file in the working directory,
+// so the sizes are exact even if the file is written meanwhile. `stop 0 .` hangs up
+// the profile command, the other child of the script, from /proc so that the
+// script archives the working directory and exits.
+const (
+	shellTransferIn     = 3
+	shellTransferOut    = 4
+	shellTransferTemp   = ".transfer.tmp"
+	shellTransferHelper = "while read -r op n name <&3; do case $op in " +
+		"put) head -c \"$n\" <&3 >" + shellTransferTemp + "; " +
+		"mkdir -p -- \"$(dirname -- \"$name\")\" 2>/dev/null && mv -f -- " + shellTransferTemp + " \"$name\" 2>/dev/null && " +
+		"echo ok 0 >&4 || { rm -f " + shellTransferTemp + "; echo err 0 >&4; };; " +
+		"get) if [ -f \"$name\" ] && cp -- \"$name\" " + shellTransferTemp + " 2>/dev/null; then " +
+		"s=$(stat -c %s " + shellTransferTemp + "); " +
+		"if [ \"$s\" -le \"$n\" ]; then echo ok \"$s\" >&4; cat " + shellTransferTemp + " >&4; else echo err \"$s\" >&4; fi; " +
+		"else echo err 0 >&4; fi; rm -f " + shellTransferTemp + ";; " +
+		"stop) for f in /proc/[0-9]*/stat; do read -r l <\"$f\" || continue; set -- ${l##*) }; " +
+		"p=${f#/proc/}; p=${p%/stat}; [ \"$2\" = \"$$\" ] && [ \"$p\" != \"$BASHPID\" ] && kill -HUP \"$p\"; done; " +
+		"echo ok 0 >&4;; " +
+		"esac; done;"
+
+	shellTransferMax     = 2 << 20               // within the default gRPC message size
+	shellTransferBufMax  = shellTransferMax + 64 // the largest reply with its line
+	shellTransferChunk   = 64 << 10
+	shellTransferTimeout = 30 * time.Second
+)
+
+// shellTransfer is the helper output of a running shell, the transfers run one at
+// a time since the helper replies in order
+type shellTransfer struct {
+	run sync.Mutex
+
+	mu       sync.Mutex
+	pending  bool // a request is waiting for the reply
+	overflow bool // the output exceeded the largest reply
+	buf      []byte
+	notify   chan struct{}
+}
+
+func newShellTransfer() *shellTransfer {
+	return &shellTransfer{notify: make(chan struct{}, 1)}
+}
+
+// Write appends the helper output, called by the output loop. The processes in
+// the sandbox could also write to fd 4 through /proc, so the output is discarded
+// if no request is waiting for it or it exceeds the largest reply.
+func (t *shellTransfer) Write(b []byte) {
+	t.mu.Lock()
+	switch {
+	case !t.pending:
+	case len(t.buf)+len(b) > shellTransferBufMax:
+		t.overflow = true
+		t.buf = nil
+	default:
+		t.buf = append(t.buf, b...)
+	}
+	t.mu.Unlock()
+	select {
+	case t.notify <- struct{}{}:
+	default:
+	}
+}
+
+// take waits until n returns the length of the complete prefix of the helper
+// output, or -1 if incomplete, and consumes it
+func (t *shellTransfer) take(ctx context.Context, n func([]byte) int) ([]byte, error) {
+	for {
+		t.mu.Lock()
+		if t.overflow {
+			t.mu.Unlock()
+			return nil, errors.New("too much output from the helper")
+		}
+		if i := n(t.buf); i >= 0 {
+			rt := bytes.Clone(t.buf[:i])
+			t.buf = append(t.buf[:0], t.buf[i:]...)
+			t.mu.Unlock()
+			return rt, nil
+		}
+		t.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.notify:
+		}
+	}
+}
+
+// begin resets the helper output for a new request, the output of the previous
+// one, e.g. late or malformed, is dropped. It is called with run held.
+func (t *shellTransfer) begin() {
+	t.mu.Lock()
+	t.pending, t.overflow, t.buf = true, false, nil
+	t.mu.Unlock()
+}
+
+// end discards the helper output until the next request. It is called with run
+// held.
+func (t *shellTransfer) end() {
+	t.mu.Lock()
+	t.pending, t.overflow, t.buf = false, false, nil
+	t.mu.Unlock()
+}
+
+// transferFile runs the transfer and returns the reply to the client
+func (sh *shellSession) transferFile(ctx context.Context, req *pb.ShellTransfer) *pb.ShellTransfer {
+	rt := pb.ShellTransfer_builder{
+		Name:     proto.String(req.GetName()),
+		Download: proto.Bool(req.GetDownload()),
+	}.Build()
+	content, err := sh.transferContent(ctx, req.GetName(), req.GetDownload(), req.GetContent())
+	if err != nil {
+		rt.SetError(err.Error())
+	} else if req.GetDownload() {
+		rt.SetContent(content)
+	}
+	return rt
+}
+
+// transferContent uploads the content into the working directory, or downloads
+// the file from it
+func (sh *shellSession) transferContent(ctx context.Context, name string, download bool, content []byte) ([]byte, error) {
+	t := sh.transfer
+	if t == nil {
+		return nil, errors.New("file transfer is not available with the shell profile")
+	}
+	name, err := cleanShellFileName(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > shellTransferMax {
+		return nil, fmt.Errorf("file exceeds %d bytes", shellTransferMax)
+	}
+
+	t.run.Lock()
+	defer t.run.Unlock()
+	t.begin()
+	defer t.end()
+	ctx, cancel := context.WithTimeout(ctx, shellTransferTimeout)
+	defer cancel()
+
+	op, size := "put", len(content)
+	if download {
+		op, size = "get", shellTransferMax
+	}
+	sh.sendTransfer(fmt.Appendf(nil, "%s %d %s\n", op, size, name))
+	for chunk := range slices.Chunk(content, shellTransferChunk) {
+		sh.sendTransfer(chunk)
+	}
+
+	result, size, err := t.reply(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case result != "ok" && size > 0:
+		return nil, fmt.Errorf("file exceeds %d bytes", shellTransferMax)
+	case result != "ok" && download:
+		return nil, fmt.Errorf("failed to download %q", name)
+	case result != "ok":
+		return nil, fmt.Errorf("failed to upload %q", name)
+	case size < 0 || size > shellTransferMax:
+		return nil, errors.New("file transfer failed: malformed reply")
+	case !download:
+		return nil, nil
+	}
+	rt, err := t.take(ctx, func(b []byte) int {
+		if len(b) < size {
+			return -1
+		}
+		return size
+	})
+	if err != nil {
+		return nil, fmt.Errorf("file transfer failed: %w", err)
+	}
+	return rt, nil
+}
+
+// reply reads the reply line of the helper. It is called with run held.
+func (t *shellTransfer) reply(ctx context.Context) (string, int, error) {
+	line, err := t.take(ctx, func(b []byte) int {
+		if i := bytes.IndexByte(b, '\n'); i >= 0 {
+			return i + 1
+		}
+		return -1
+	})
+	var (
+		result string
+		size   int
+	)
+	if err == nil {
+		_, err = fmt.Sscanf(string(line), "%s %d\n", &result, &size)
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("file transfer failed: %w", err)
+	}
+	return result, size, nil
+}
+
+// hangup asks the helper to hang up the profile command, the reply is not waited
+// as the helper is killed once the command exits
+func (sh *shellSession) hangup() {
+	t := sh.transfer
+	t.run.Lock()
+	defer t.run.Unlock()
+	sh.sendTransfer([]byte("stop 0 .\n"))
+}
+
+func (sh *shellSession) sendTransfer(b []byte) {
+	sh.send(execpb.StreamRequest_builder{ExecInput: execpb.StreamRequest_Input_builder{
+		Fd:      shellTransferIn,
+		Content: b,
+	}.Build()}.Build())
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShellTransferOutput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tr := newShellTransfer()
+	tr.Write([]byte("ok 3\nabc")) // written by the sandbox without a request
+	tr.begin()
+	tr.Write([]byte("ok 0\n"))
+	if result, size, err := tr.reply(ctx); err != nil || result != "ok" || size != 0 {
+		t.Fatalf("reply = %q, %d, %v", result, size, err)
+	}
+	tr.end()
+
+	tr.begin()
+	tr.Write(bytes.Repeat([]byte{'a'}, shellTransferBufMax))
+	tr.Write([]byte("\n"))
+	if _, _, err := tr.reply(ctx); err == nil {
+		t.Fatal("reply succeeded after overflow")
+	}
+	tr.end()
+
+	// malformed output of the failed request does not stay
+	tr.begin()
+	tr.Write([]byte("garbage\n"))
+	if _, _, err := tr.reply(ctx); err == nil {
+		t.Fatal("malformed reply accepted")
+	}
+	tr.Write([]byte("late"))
+	tr.end()
+	tr.begin()
+	tr.Write([]byte("err 0\n"))
+	if result, _, err := tr.reply(ctx); err != nil || result != "err" {
+		t.Fatalf("reply = %q, %v", result, err)
+	}
+	tr.end()
+}
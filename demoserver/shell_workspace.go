@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+	execpb "github.com/criyle/go-judge/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// shellArchiveCheck is the interval to expire the shell archives
+const shellArchiveCheck = time.Hour
+
+// workspaceUser returns the logged in user, anonymous shells are not kept
+func workspaceUser(ctx context.Context) (string, error) {
+	u := userFromContext(ctx)
+	if u.ID == "" {
+		return "", status.Errorf(codes.Unauthenticated, "shell workspace requires login")
+	}
+	return u.ID, nil
+}
+
+func (s *demoServer) GetShellWorkspace(ctx context.Context, req *pb.GetShellWorkspaceRequest) (*pb.ShellWorkspace, error) {
+	userID, err := workspaceUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.db.GetShellWorkspace(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertShellWorkspace(ws), nil
+}
+
+// ResetShellWorkspace empties the workspace, the running shells are not affected
+func (s *demoServer) ResetShellWorkspace(ctx context.Context, req *pb.ResetShellWorkspaceRequest) (*pb.ShellWorkspace, error) {
+	userID, err := workspaceUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.db.DeleteShellWorkspace(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if ws != nil {
+		s.releaseBlob(ws.Archive)
+	}
+	return s.convertShellWorkspace(nil), nil
+}
+
+func (s *demoServer) convertShellWorkspace(ws *ShellWorkspace) *pb.ShellWorkspace {
+	rt := pb.ShellWorkspace_builder{Quota: proto.Uint64(uint64(s.files.workspaceMax))}.Build()
+	if ws != nil {
+		rt.SetSize(uint64(ws.Size))
+		rt.SetUpdated(timestamppb.New(*ws.Updated))
+	}
+	return rt
+}
+
+// loadShellWorkspace adds the workspace archive of the user to the copy in, it is
+// extracted before the interactive shell starts
+func (s *demoServer) loadShellWorkspace(ctx context.Context, userID string, copyIn map[string]*execpb.Request_File) {
+	if userID == "" || s.files.workspaceMax <= 0 {
+		return
+	}
+	ws, err := s.db.GetShellWorkspace(ctx, userID)
+	if err != nil {
+		s.logger.Warn("get shell workspace", zap.String("userId", userID), zap.Error(err))
+		return
+	}
+	if ws == nil {
+		return
+	}
+	content, err := s.blob.Get(ctx, ws.Archive)
+	if err != nil {
+		s.logger.Warn("get shell workspace archive", zap.String("userId", userID), zap.Error(err))
+		return
+	}
+	copyIn[shellArchive] = execpb.Request_File_builder{
+		Memory: execpb.Request_MemoryFile_builder{Content: content}.Build(),
+	}.Build()
+}
+
+// saveShellWorkspace keeps the archive as the workspace of the user, the previous
+// one is kept if it is over the quota
+func (s *demoServer) saveShellWorkspace(userID, hash string, size int) {
+	if userID == "" || hash == "" || s.files.workspaceMax <= 0 {
+		return
+	}
+	if size > s.files.workspaceMax {
+		s.logger.Info("shell workspace over quota", zap.String("userId", userID), zap.Int("size", size))
+		return
+	}
+	now := time.Now()
+	prev, err := s.db.SetShellWorkspace(context.TODO(), &ShellWorkspace{
+		UserID:  userID,
+		Archive: hash,
+		Size:    size,
+		Updated: &now,
+	})
+	if err != nil {
+		s.logger.Warn("save shell workspace", zap.String("userId", userID), zap.Error(err))
+		return
+	}
+	if prev != nil && prev.Archive != hash {
+		s.releaseBlob(prev.Archive)
+	}
+}
+
+// shellArchiveLoop expires the archives of the sessions ended longer than the
+// retention, the workspaces are kept
+func (s *demoServer) shellArchiveLoop(retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(shellArchiveCheck)
+	defer ticker.Stop()
+	for {
+		hashes, err := s.db.ExpireShellArchives(context.TODO(), time.Now().Add(-retention))
+		if err != nil {
+			s.logger.Warn("expire shell archives", zap.Error(err))
+		}
+		for _, hash := range hashes {
+			s.releaseBlob(hash)
+		}
+		<-ticker.C
+	}
+}
+
+// releaseBlob deletes the archive from the blob store once nothing references it.
+// The same content stored meanwhile is not tracked, it is unlikely for archives.
+func (s *demoServer) releaseBlob(hash string) {
+	ctx := context.TODO()
+	referenced, err := s.db.BlobReferenced(ctx, hash)
+	if err != nil {
+		s.logger.Warn("check blob references", zap.String("hash", hash), zap.Error(err))
+		return
+	}
+	if referenced {
+		return
+	}
+	if err := s.blob.Delete(ctx, hash); err != nil {
+		s.logger.Warn("delete blob", zap.String("hash", hash), zap.Error(err))
+	}
+}
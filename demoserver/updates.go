@@ -0,0 +1,115 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge-demo/pb"
+)
+
+// updateMeta is the owner and language of a submission being judged, the judger
+// updates do not carry them
+type updateMeta struct {
+	userID   string
+	language *pb.Language
+	waiter   chan *pb.JudgeUpdate // see Run
+}
+
+// submissionMeta tracks updateMeta of the submissions until finished
+type submissionMeta struct {
+	mu sync.Mutex
+	m  map[string]updateMeta
+}
+
+func newSubmissionMeta() *submissionMeta {
+	return &submissionMeta{m: make(map[string]updateMeta)}
+}
+
+func (s *submissionMeta) Store(id string, m updateMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[id] = m
+}
+
+// Annotate fills the owner and language of the update, and forgets the submission
+// once finished
+func (s *submissionMeta) Annotate(up *pb.JudgeUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.m[up.GetId()]
+	if !ok {
+		return
+	}
+	up.SetUserId(m.userID)
+	if !up.HasLanguage() {
+		up.SetLanguage(m.language)
+	}
+	if m.waiter != nil {
+		notifyWaiter(m.waiter, up)
+	}
+	if up.GetType() == "finished" {
+		delete(s.m, up.GetId())
+	}
+}
+
+// notifyWaiter never blocks, the earlier updates are dropped if the waiter is
+// behind so that the finished one is always delivered
+func notifyWaiter(w chan *pb.JudgeUpdate, up *pb.JudgeUpdate) {
+	for {
+		select {
+		case w <- up:
+			return
+		default:
+		}
+		if up.GetType() != "finished" {
+			return
+		}
+		select {
+		case <-w:
+		default:
+		}
+	}
+}
+
+// replayBuffer assigns the sequence numbers and keeps the latest updates so that
+// observers could resume after reconnect. The sequence starts from the start time
+// in microseconds to keep increasing across restarts. It is owned by updateLoop.
+type replayBuffer struct {
+	max   int
+	seq   uint64 // last assigned
+	items []*pb.JudgeUpdate
+	next  int // ring position of the oldest once full
+}
+
+func newReplayBuffer(max int) *replayBuffer {
+	return &replayBuffer{max: max, seq: uint64(time.Now().UnixMicro())}
+}
+
+// Add assigns the next sequence number to the update and stores it
+func (b *replayBuffer) Add(up *pb.JudgeUpdate) {
+	b.seq++
+	up.SetSeq(b.seq)
+	switch {
+	case b.max <= 0:
+	case len(b.items) < b.max:
+		b.items = append(b.items, up)
+	default:
+		b.items[b.next] = up
+		b.next = (b.next + 1) % b.max
+	}
+}
+
+// Since returns the updates after the seq, or false if some of them are no longer
+// available
+func (b *replayBuffer) Since(since uint64) ([]*pb.JudgeUpdate, bool) {
+	if since == 0 || since == b.seq {
+		return nil, true
+	}
+	n := b.seq - since
+	if since > b.seq || n > uint64(len(b.items)) {
+		return nil, false
+	}
+	ordered := append(slices.Clone(b.items[b.next:]), b.items[:b.next]...)
+	return ordered[len(ordered)-int(n):], true
+}
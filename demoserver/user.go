@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/criyle/go-judge-demo/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// metadata keys set by apigateway for the logged in user and the client
+const (
+	mdUserID    = "x-user-id"
+	mdUserName  = "x-user-name"
+	mdUserRoles = "x-user-roles"
+	mdClientIP  = "x-client-ip"
+)
+
+const (
+	roleUser  = "user"
+	roleAdmin = "admin"
+)
+
+// userInfo is the identity of the end user forwarded by apigateway
+type userInfo struct {
+	ID    string
+	Name  string
+	Roles []string
+	IP    string
+}
+
+// userFromContext reads the forwarded user identity, the ID is empty for anonymous requests
+func userFromContext(ctx context.Context) userInfo {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(k string) string {
+		if v := md.Get(k); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	u := userInfo{ID: get(mdUserID), Name: get(mdUserName), IP: get(mdClientIP)}
+	if r := get(mdUserRoles); r != "" {
+		u.Roles = strings.Split(r, ",")
+	}
+	return u
+}
+
+func (s *demoServer) CreateUser(ctx context.Context, req *pb.User) (*pb.User, error) {
+	if req.GetName() == "" || len(req.GetPasswordHash()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "name and password are required")
+	}
+	u, err := s.db.AddUser(ctx, &User{
+		Name:         req.GetName(),
+		PasswordHash: req.GetPasswordHash(),
+		Roles:        []string{roleUser},
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "user %q already exists", req.GetName())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.convertUser(u), nil
+}
+
+func (s *demoServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	if req.GetId() != "" {
+		if _, err := bson.ObjectIDFromHex(req.GetId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", req.GetId())
+		}
+	}
+	u, err := s.db.GetUser(ctx, req.GetId(), req.GetName())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.convertUser(u), nil
+}
+
+// VerifyUser returns the user if the password matches, unknown names and wrong
+// passwords both fail with NotFound
+func (s *demoServer) VerifyUser(ctx context.Context, req *pb.VerifyUserRequest) (*pb.User, error) {
+	u, err := s.db.GetUser(ctx, "", req.GetName())
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if err != nil || bcrypt.CompareHashAndPassword(u.PasswordHash, []byte(req.GetPassword())) != nil {
+		return nil, status.Errorf(codes.NotFound, "invalid user name or password")
+	}
+	return s.convertUser(u), nil
+}
+
+// convertUser also grants admin role to the users configured by ADMIN_USERS
+func (s *demoServer) convertUser(u *User) *pb.User {
+	id := u.ID.Hex()
+	roles := slices.Clone(u.Roles)
+	if slices.Contains(s.admins, u.Name) && !slices.Contains(roles, roleAdmin) {
+		roles = append(roles, roleAdmin)
+	}
+	rt := pb.User_builder{
+		Id:    &id,
+		Name:  &u.Name,
+		Roles: roles,
+	}.Build()
+	if u.Date != nil {
+		rt.SetDate(timestamppb.New(*u.Date))
+	}
+	return rt
+}
@@ -0,0 +1,23 @@
+// Package judgeupdate filters the judge updates streamed by demo server and
+// forwarded to the clients by apigateway
+package judgeupdate
+
+import (
+	"slices"
+
+	"github.com/criyle/go-judge-demo/pb"
+)
+
+// Match checks the update against the filter, empty fields match all
+func Match(f *pb.UpdatesRequest, up *pb.JudgeUpdate) bool {
+	if len(f.GetIds()) > 0 && !slices.Contains(f.GetIds(), up.GetId()) {
+		return false
+	}
+	if f.GetUserId() != "" && f.GetUserId() != up.GetUserId() {
+		return false
+	}
+	if f.GetLanguage() != "" && f.GetLanguage() != up.GetLanguage().GetName() {
+		return false
+	}
+	return true
+}
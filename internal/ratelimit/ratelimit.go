@@ -0,0 +1,152 @@
+// Package ratelimit limits the requests per client by token buckets and the
+// concurrent sessions per client, shared by apigateway and demo server which
+// identify the clients differently
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const limiterIdle = 10 * time.Minute
+
+var (
+	// ErrTotal is returned by Acquire when the total cap is reached
+	ErrTotal = errors.New("total concurrency limit reached")
+	// ErrPerKey is returned by Acquire when the cap of the key is reached
+	ErrPerKey = errors.New("concurrency limit reached for the client")
+)
+
+// ParseRate parses `<n>/<duration>` as n events per duration with burst n,
+// e.g. `10/1m`, empty or `0` disables the limit
+func ParseRate(s string) (rate.Limit, int, error) {
+	if s == "" || s == "0" {
+		return rate.Inf, 0, nil
+	}
+	n, d, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid rate %q, expect <n>/<duration>", s)
+	}
+	burst, err := strconv.Atoi(n)
+	if err != nil || burst <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate %q: bad count", s)
+	}
+	per, err := time.ParseDuration(d)
+	if err != nil || per <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate %q: bad duration", s)
+	}
+	return rate.Every(per / time.Duration(burst)), burst, nil
+}
+
+// Limiter is a token bucket per client key
+type Limiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*keyLimiter
+}
+
+type keyLimiter struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+func NewLimiter(limit rate.Limit, burst int) *Limiter {
+	l := &Limiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: make(map[string]*keyLimiter),
+	}
+	if limit != rate.Inf {
+		go l.cleanupLoop()
+	}
+	return l
+}
+
+// Unlimited reports whether the limit is disabled
+func (l *Limiter) Unlimited() bool {
+	return l.limit == rate.Inf
+}
+
+// Get returns the token bucket of the key
+func (l *Limiter) Get(key string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	kl, ok := l.limiters[key]
+	if !ok {
+		kl = &keyLimiter{Limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = kl
+	}
+	kl.lastSeen = time.Now()
+	return kl.Limiter
+}
+
+// cleanupLoop removes idle clients, their buckets are full again anyway
+func (l *Limiter) cleanupLoop() {
+	for range time.Tick(limiterIdle) {
+		l.mu.Lock()
+		for k, kl := range l.limiters {
+			if time.Since(kl.lastSeen) > limiterIdle {
+				delete(l.limiters, k)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Concurrency caps the number of concurrent sessions per client key and in total
+type Concurrency struct {
+	perKey, total int // 0 for unlimited
+
+	mu    sync.Mutex
+	count int
+	keys  map[string]int
+}
+
+func NewConcurrency(perKey, total int) *Concurrency {
+	return &Concurrency{
+		perKey: perKey,
+		total:  total,
+		keys:   make(map[string]int),
+	}
+}
+
+// Limits returns the caps per key and in total
+func (l *Concurrency) Limits() (perKey, total int) {
+	return l.perKey, l.total
+}
+
+// Acquire returns the release function, or ErrTotal / ErrPerKey if either cap is reached
+func (l *Concurrency) Acquire(key string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.total > 0 && l.count >= l.total {
+		return nil, ErrTotal
+	}
+	if l.perKey > 0 && l.keys[key] >= l.perKey {
+		return nil, ErrPerKey
+	}
+	l.count++
+	l.keys[key]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.count--
+			if l.keys[key]--; l.keys[key] <= 0 {
+				delete(l.keys, key)
+			}
+		})
+	}, nil
+}
@@ -0,0 +1,182 @@
+// Package tlsfile loads TLS certificates from files for the gRPC connections
+// between the services, reloaded after the files changed so that certificates
+// could be rotated without restart
+package tlsfile
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Files loads the certificate, key and CA from files and reloads them on
+// the next handshake after any of them was changed
+type Files struct {
+	certFile, keyFile, caFile string
+
+	mu      sync.Mutex
+	content [][]byte // content of the loaded files, nil if not loaded yet
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+// New returns nil if none of the files are set, that is, TLS is disabled
+func New(certFile, keyFile, caFile string) (*Files, error) {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return nil, nil
+	}
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("TLS certificate and key must be set together")
+	}
+	f := &Files{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if _, _, err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// HasCert reports whether the certificate is configured
+func (f *Files) HasCert() bool {
+	return f != nil && f.certFile != ""
+}
+
+// load returns the current certificate and CA pool, either could be nil if not configured.
+// Failed reloads keep the previous ones so a partially written file does not break serving.
+func (f *Files) load() (*tls.Certificate, *x509.CertPool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// files are small and only read on handshake, compare the content as
+	// modification time is not precise enough on some file systems
+	content := make([][]byte, 3)
+	for i, n := range []string{f.certFile, f.keyFile, f.caFile} {
+		if n == "" {
+			continue
+		}
+		b, err := os.ReadFile(n)
+		if err != nil {
+			return f.loaded(err)
+		}
+		content[i] = b
+	}
+	if f.content != nil && slices.EqualFunc(content, f.content, bytes.Equal) {
+		return f.cert, f.pool, nil
+	}
+
+	var (
+		cert *tls.Certificate
+		pool *x509.CertPool
+	)
+	if f.certFile != "" {
+		c, err := tls.X509KeyPair(content[0], content[1])
+		if err != nil {
+			return f.loaded(err)
+		}
+		cert = &c
+	}
+	if f.caFile != "" {
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(content[2]) {
+			return f.loaded(fmt.Errorf("no certificate found in %s", f.caFile))
+		}
+	}
+	f.content, f.cert, f.pool = content, cert, pool
+	return cert, pool, nil
+}
+
+func (f *Files) loaded(err error) (*tls.Certificate, *x509.CertPool, error) {
+	if f.content == nil {
+		return nil, nil, err
+	}
+	return f.cert, f.pool, nil
+}
+
+// ServerCredentials serves with the certificate, client certificates are
+// required and verified against the CA of clientCA if not nil (mutual TLS)
+func (f *Files) ServerCredentials(clientCA *Files) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, _, err := f.load()
+			if err != nil {
+				return nil, err
+			}
+			if cert == nil {
+				return nil, errors.New("TLS certificate is not set")
+			}
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   []string{"h2"},
+			}
+			if clientCA != nil {
+				_, pool, err := clientCA.load()
+				if err != nil {
+					return nil, err
+				}
+				if pool == nil {
+					return nil, errors.New("TLS client CA is not set")
+				}
+				c.ClientCAs = pool
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return c, nil
+		},
+	})
+}
+
+// ClientCredentials verifies the server against the CA (system roots if not set)
+// and presents the certificate if the server asks for one
+func (f *Files) ClientCredentials(serverName string) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		// verified by VerifyConnection with the reloaded CA
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, pool, err := f.load()
+			if err != nil {
+				return err
+			}
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no server certificate")
+			}
+			intermediates := x509.NewCertPool()
+			for _, c := range cs.PeerCertificates[1:] {
+				intermediates.AddCert(c)
+			}
+			_, err = cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+				DNSName:       cs.ServerName,
+				Roots:         pool,
+				Intermediates: intermediates,
+			})
+			return err
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := f.load()
+			if err != nil {
+				return nil, err
+			}
+			if cert == nil {
+				return &tls.Certificate{}, nil
+			}
+			return cert, nil
+		},
+	})
+}
+
+// DialCredentials returns the client transport credentials, insecure if TLS is disabled
+func DialCredentials(f *Files, serverName string) credentials.TransportCredentials {
+	if f == nil {
+		return insecure.NewCredentials()
+	}
+	return f.ClientCredentials(serverName)
+}
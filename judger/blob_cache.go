@@ -0,0 +1,221 @@
+package main
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	demopb "github.com/criyle/go-judge-demo/pb"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// blobCache keeps test data fetched from demo server on local disk, evicting the
+// least recently used blobs when the total size exceeds the limit
+type blobCache struct {
+	dir        string
+	maxSize    int64
+	demoClient demopb.DemoBackendClient
+
+	group singleflight.Group
+
+	mu    sync.Mutex
+	size  int64
+	lru   *list.List // front is the most recently used
+	index map[string]*list.Element
+}
+
+type blobEntry struct {
+	hash string
+	size int64
+}
+
+func newBlobCache(dir string, maxSize int64, demoClient demopb.DemoBackendClient) (*blobCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	c := &blobCache{
+		dir:        dir,
+		maxSize:    maxSize,
+		demoClient: demoClient,
+		lru:        list.New(),
+		index:      make(map[string]*list.Element),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load rebuilds the index from files left by the previous run, ordered by modification time
+func (c *blobCache) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	type file struct {
+		blobEntry
+		mod int64
+	}
+	files := make([]file, 0, len(entries))
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		if _, err := hex.DecodeString(e.Name()); err != nil || len(e.Name()) != sha256.Size*2 {
+			// partial downloads
+			os.Remove(filepath.Join(c.dir, e.Name()))
+			continue
+		}
+		files = append(files, file{blobEntry{e.Name(), fi.Size()}, fi.ModTime().UnixNano()})
+	}
+	slices.SortFunc(files, func(a, b file) int { return cmp.Compare(b.mod, a.mod) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range files {
+		c.index[f.hash] = c.lru.PushBack(&f.blobEntry)
+		c.size += f.size
+	}
+	c.evictLocked()
+	return nil
+}
+
+// Get returns the blob content, downloading it through FetchBlob on cache miss
+func (c *blobCache) Get(ctx context.Context, hash string) ([]byte, error) {
+	if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha256.Size*2 {
+		return nil, fmt.Errorf("invalid blob hash %q", hash)
+	}
+	if b, ok := c.read(hash); ok {
+		return b, nil
+	}
+	_, err, _ := c.group.Do(hash, func() (any, error) {
+		return nil, c.fetch(ctx, hash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := c.read(hash); ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("blob %q evicted", hash)
+}
+
+func (c *blobCache) read(hash string) ([]byte, bool) {
+	c.mu.Lock()
+	e, ok := c.index[hash]
+	if ok {
+		c.lru.MoveToFront(e)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	b, err := os.ReadFile(filepath.Join(c.dir, hash))
+	if err != nil {
+		c.remove(hash)
+		return nil, false
+	}
+	return b, true
+}
+
+func (c *blobCache) fetch(ctx context.Context, hash string) error {
+	fc, err := c.demoClient.FetchBlob(ctx, demopb.FetchBlobRequest_builder{Hash: &hash}.Build())
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.dir, "fetch-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	w := io.MultiWriter(f, h)
+	var size int64
+	for {
+		chunk, err := fc.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		n, err := w.Write(chunk.GetContent())
+		if err != nil {
+			return err
+		}
+		size += int64(n)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != hash {
+		return fmt.Errorf("blob %q hash mismatch: %q", hash, got)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(c.dir, hash)); err != nil {
+		return err
+	}
+	logger.Debug("blob fetched", zap.String("hash", hash), zap.Int64("size", size))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.index[hash]; !ok {
+		c.index[hash] = c.lru.PushFront(&blobEntry{hash: hash, size: size})
+		c.size += size
+	}
+	c.evictLocked()
+	return nil
+}
+
+func (c *blobCache) remove(hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.index[hash]; ok {
+		c.removeLocked(e)
+	}
+}
+
+// evictLocked removes the least recently used blobs but always keeps the latest one
+func (c *blobCache) evictLocked() {
+	for c.size > c.maxSize && c.lru.Len() > 1 {
+		c.removeLocked(c.lru.Back())
+	}
+}
+
+func (c *blobCache) removeLocked(e *list.Element) {
+	be := c.lru.Remove(e).(*blobEntry)
+	delete(c.index, be.hash)
+	c.size -= be.size
+	os.Remove(filepath.Join(c.dir, be.hash))
+}
+
+// testData resolves the content of a test case, either inline or by hash
+func (j *judger) testData(ctx context.Context, ia *demopb.InputAnswer) (string, string, error) {
+	input, answer := ia.GetInput(), ia.GetAnswer()
+	if h := ia.GetInputHash(); h != "" {
+		b, err := j.blob.Get(ctx, h)
+		if err != nil {
+			return "", "", err
+		}
+		input = string(b)
+	}
+	if h := ia.GetAnswerHash(); h != "" {
+		b, err := j.blob.Get(ctx, h)
+		if err != nil {
+			return "", "", err
+		}
+		answer = string(b)
+	}
+	return input, answer, nil
+}
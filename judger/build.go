@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	demopb "github.com/criyle/go-judge-demo/pb"
+	"github.com/criyle/go-judge/pb"
+	"github.com/google/shlex"
+	"go.uber.org/zap"
+)
+
+const maxGeneratedSize = 16 << 20 // 16m per generated input / answer
+
+// buildLog collects the build log sent back with the finished response
+type buildLog struct {
+	strings.Builder
+}
+
+func (b *buildLog) Logf(format string, args ...any) {
+	fmt.Fprintf(b, format, args...)
+	b.WriteByte('\n')
+}
+
+// buildSingle runs the generator script: each line invokes a generator whose output is
+// validated and answered by the reference solution. Every generated test is sent back
+// in its own progress response to stay under the message size limit.
+func (j *judger) buildSingle(req *demopb.JudgeClientRequest) {
+	sTime := time.Now()
+	ctx := context.TODO()
+	build := req.GetBuildProblem()
+	log := new(buildLog)
+
+	finish := func(status string) {
+		rt := judgeClientResponse(req.GetId(), "finished", status)
+		rt.SetLog(log.String())
+		j.response <- rt
+		t := time.Since(sTime)
+		taskHist.WithLabelValues("build").Observe(t.Seconds())
+		taskSummry.WithLabelValues("build").Observe(t.Seconds())
+	}
+
+	// compile
+	j.response <- judgeClientResponse(req.GetId(), "progress", "Compiling")
+	programs := make(map[string]*demopb.Program)
+	compiled := make(map[*demopb.Program]map[string]string)
+	defer func() {
+		for _, fileIDs := range compiled {
+			j.deleteFiles(fileIDs)
+		}
+	}()
+	all := append([]*demopb.Program{build.GetSolution()}, build.GetGenerators()...)
+	if build.HasValidator() {
+		all = append(all, build.GetValidator())
+	}
+	for _, p := range all {
+		fileIDs, err := j.compileProgram(ctx, p.GetLanguage(), p.GetSource(), p.GetFiles())
+		if err != nil {
+			log.Logf("compile %s: %v", p.GetName(), err)
+			finish("Compile Error")
+			return
+		}
+		compiled[p] = fileIDs
+		log.Logf("compiled %s", p.GetName())
+	}
+	for _, g := range build.GetGenerators() {
+		programs[g.GetName()] = g
+	}
+
+	// generate
+	lines := buildScript(build.GetScript())
+	if len(lines) == 0 {
+		log.Logf("empty script")
+		finish("Failed")
+		return
+	}
+	for i, line := range lines {
+		args, err := shlex.Split(line)
+		if err != nil {
+			log.Logf("line %d: %v", i+1, err)
+			finish("Failed")
+			return
+		}
+		if len(args) == 0 {
+			log.Logf("line %d: empty command", i+1)
+			finish("Failed")
+			return
+		}
+		gen, ok := programs[args[0]]
+		if !ok {
+			log.Logf("line %d: unknown generator %q", i+1, args[0])
+			finish("Failed")
+			return
+		}
+		ia, err := j.buildTest(ctx, build, gen, compiled, args[1:])
+		if err != nil {
+			log.Logf("test %d (%s): %v", i+1, line, err)
+			finish("Failed")
+			return
+		}
+		log.Logf("test %d (%s): input %d bytes, answer %d bytes", i+1, line, len(ia.GetInput()), len(ia.GetAnswer()))
+
+		rt := judgeClientResponse(req.GetId(), "progress", fmt.Sprintf("Generating (%d / %d)", i+1, len(lines)))
+		rt.SetInputAnswer([]*demopb.InputAnswer{ia})
+		j.response <- rt
+	}
+	finish("Accepted")
+}
+
+// buildTest generates a single input, validates it and produces the answer
+func (j *judger) buildTest(ctx context.Context, build *demopb.BuildProblemRequest, gen *demopb.Program, compiled map[*demopb.Program]map[string]string, args []string) (*demopb.InputAnswer, error) {
+	genRet, err := j.runProgram(ctx, gen, compiled[gen], args, emptyFile(), true)
+	if err != nil {
+		return nil, fmt.Errorf("generator: %w", err)
+	}
+	inputID := genRet.GetFileIDs()["stdout"]
+	defer j.deleteFiles(genRet.GetFileIDs())
+	input := cachedFile(inputID)
+
+	if build.HasValidator() {
+		if _, err := j.runProgram(ctx, build.GetValidator(), compiled[build.GetValidator()], nil, input, false); err != nil {
+			return nil, fmt.Errorf("validator: %w", err)
+		}
+	}
+	solRet, err := j.runProgram(ctx, build.GetSolution(), compiled[build.GetSolution()], nil, input, false)
+	if err != nil {
+		return nil, fmt.Errorf("solution: %w", err)
+	}
+	inputContent, err := j.execClient.FileGet(ctx, pb.FileID_builder{FileID: inputID}.Build())
+	if err != nil {
+		return nil, err
+	}
+	in := string(inputContent.GetContent())
+	ans := string(solRet.GetFiles()["stdout"])
+	return demopb.InputAnswer_builder{
+		Input:  &in,
+		Answer: &ans,
+	}.Build(), nil
+}
+
+// runProgram runs the compiled program with stdin, the stdout is cached in the
+// exec server file store if cacheStdout, otherwise returned with the result
+func (j *judger) runProgram(ctx context.Context, p *demopb.Program, fileIDs map[string]string, extraArgs []string, stdin *pb.Request_File, cacheStdout bool) (*pb.Response_Result, error) {
+	args, err := shlex.Split(p.GetLanguage().GetRunCmd())
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, extraArgs...)
+	copyIn := make(map[string]*pb.Request_File)
+	for k, v := range fileIDs {
+		copyIn[k] = cachedFile(v)
+	}
+	stdout := []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stdout"}.Build()}
+	copyOut := []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()}
+	var copyOutCached []*pb.Request_CmdCopyOutFile
+	if cacheStdout {
+		copyOutCached = stdout
+	} else {
+		copyOut = append(copyOut, stdout...)
+	}
+	ret, err := j.execClient.Exec(ctx, pb.Request_builder{
+		Cmd: []*pb.Request_CmdType{pb.Request_CmdType_builder{
+			Args: args,
+			Env:  env,
+			Files: []*pb.Request_File{
+				stdin,
+				pb.Request_File_builder{
+					Pipe: pb.Request_PipeCollector_builder{
+						Name: "stdout",
+						Max:  maxGeneratedSize,
+					}.Build(),
+				}.Build(),
+				pb.Request_File_builder{
+					Pipe: pb.Request_PipeCollector_builder{
+						Name: "stderr",
+						Max:  4096,
+					}.Build(),
+				}.Build(),
+			},
+			CpuTimeLimit:   uint64(10 * time.Second),
+			ClockTimeLimit: uint64(20 * time.Second),
+			MemoryLimit:    memoryLimit,
+			StackLimit:     memoryLimit,
+			ProcLimit:      languageProcLimit(p.GetLanguage().GetName()),
+			CopyIn:         copyIn,
+			CopyOut:        copyOut,
+			CopyOutCached:  copyOutCached,
+		}.Build()},
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	if ret.GetError() != "" {
+		return nil, errors.New(ret.GetError())
+	}
+	r := ret.GetResults()[0]
+	if r.GetStatus() != pb.Response_Result_Accepted {
+		j.deleteFiles(r.GetFileIDs())
+		logger.Debug("build program failed", zap.String("name", p.GetName()), zap.Any("result", r))
+		return nil, fmt.Errorf("%v %s", r.GetStatus(), r.GetFiles()["stderr"])
+	}
+	return r, nil
+}
+
+// buildScript returns the non-empty lines of the script, `#` starts a comment
+func buildScript(script string) []string {
+	var rt []string
+	for _, l := range strings.Split(script, "\n") {
+		if i := strings.IndexByte(l, '#'); i >= 0 {
+			l = l[:i]
+		}
+		if l = strings.TrimSpace(l); l != "" {
+			rt = append(rt, l)
+		}
+	}
+	return rt
+}
+
+func emptyFile() *pb.Request_File {
+	return pb.Request_File_builder{
+		Memory: pb.Request_MemoryFile_builder{
+			Content: []byte{},
+		}.Build(),
+	}.Build()
+}
+
+func cachedFile(fileID string) *pb.Request_File {
+	return pb.Request_File_builder{
+		Cached: pb.Request_CachedFile_builder{
+			FileID: fileID,
+		}.Build(),
+	}.Build()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	demopb "github.com/criyle/go-judge-demo/pb"
+	"github.com/criyle/go-judge/pb"
+	"github.com/google/shlex"
+)
+
+// compileChecker compiles the testlib style checker and returns the cached executables
+func (j *judger) compileChecker(ctx context.Context, checker *demopb.Checker) (map[string]string, error) {
+	return j.compileProgram(ctx, checker.GetLanguage(), checker.GetSource(), checker.GetFiles())
+}
+
+// compileProgram compiles auxiliary programs (checker, generator, validator) with
+// relaxed limits and returns the cached executables
+func (j *judger) compileProgram(ctx context.Context, lang *demopb.Language, source string, files []*demopb.SourceFile) (map[string]string, error) {
+	args, err := shlex.Split(lang.GetCompileCmd())
+	if err != nil {
+		return nil, err
+	}
+	copyIn := map[string]*pb.Request_File{
+		lang.GetSourceFileName(): pb.Request_File_builder{
+			Memory: pb.Request_MemoryFile_builder{
+				Content: []byte(source),
+			}.Build(),
+		}.Build(),
+	}
+	for _, f := range files {
+		copyIn[f.GetName()] = pb.Request_File_builder{
+			Memory: pb.Request_MemoryFile_builder{
+				Content: f.GetContent(),
+			}.Build(),
+		}.Build()
+	}
+	copyOut := make([]*pb.Request_CmdCopyOutFile, 0)
+	for _, f := range strings.Fields(lang.GetExecutables()) {
+		copyOut = append(copyOut, pb.Request_CmdCopyOutFile_builder{Name: f}.Build())
+	}
+	ret, err := j.execClient.Exec(ctx, pb.Request_builder{
+		Cmd: []*pb.Request_CmdType{pb.Request_CmdType_builder{
+			Args: args,
+			Env:  env,
+			Files: []*pb.Request_File{
+				pb.Request_File_builder{
+					Memory: pb.Request_MemoryFile_builder{
+						Content: []byte{},
+					}.Build(),
+				}.Build(),
+				pb.Request_File_builder{
+					Pipe: pb.Request_PipeCollector_builder{
+						Name: "stdout",
+						Max:  4096,
+					}.Build(),
+				}.Build(),
+				pb.Request_File_builder{
+					Pipe: pb.Request_PipeCollector_builder{
+						Name: "stderr",
+						Max:  4096,
+					}.Build(),
+				}.Build(),
+			},
+			CpuTimeLimit:   uint64(30 * time.Second),
+			ClockTimeLimit: uint64(60 * time.Second),
+			MemoryLimit:    memoryLimit,
+			ProcLimit:      100,
+			CopyIn:         copyIn,
+			CopyOut:        []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stdout"}.Build(), pb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()},
+			CopyOutCached:  copyOut,
+		}.Build()},
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	if ret.GetError() != "" {
+		return nil, fmt.Errorf("%v", ret.GetError())
+	}
+	r := ret.GetResults()[0]
+	if r.GetStatus() != pb.Response_Result_Accepted {
+		j.deleteFiles(r.GetFileIDs())
+		return nil, fmt.Errorf("%v %s", r.GetStatus(), r.GetFiles()["stderr"])
+	}
+	return r.GetFileIDs(), nil
+}
+
+// runChecker runs `checker input output answer`, any exit status other than 0 is wrong answer
+func (j *judger) runChecker(ctx context.Context, checker *demopb.Checker, fileIDs map[string]string, input, output, answer []byte) (bool, string, error) {
+	args, err := shlex.Split(checker.GetLanguage().GetRunCmd())
+	if err != nil {
+		return false, "", err
+	}
+	args = append(args, "input", "output", "answer")
+
+	copyIn := map[string]*pb.Request_File{
+		"input":  pb.Request_File_builder{Memory: pb.Request_MemoryFile_builder{Content: input}.Build()}.Build(),
+		"output": pb.Request_File_builder{Memory: pb.Request_MemoryFile_builder{Content: output}.Build()}.Build(),
+		"answer": pb.Request_File_builder{Memory: pb.Request_MemoryFile_builder{Content: answer}.Build()}.Build(),
+	}
+	for k, v := range fileIDs {
+		copyIn[k] = pb.Request_File_builder{
+			Cached: pb.Request_CachedFile_builder{
+				FileID: v,
+			}.Build(),
+		}.Build()
+	}
+	ret, err := j.execClient.Exec(ctx, pb.Request_builder{
+		Cmd: []*pb.Request_CmdType{pb.Request_CmdType_builder{
+			Args: args,
+			Env:  env,
+			Files: []*pb.Request_File{
+				pb.Request_File_builder{
+					Memory: pb.Request_MemoryFile_builder{
+						Content: []byte{},
+					}.Build(),
+				}.Build(),
+				pb.Request_File_builder{
+					Pipe: pb.Request_PipeCollector_builder{
+						Name: "stdout",
+						Max:  4096,
+					}.Build(),
+				}.Build(),
+				pb.Request_File_builder{
+					Pipe: pb.Request_PipeCollector_builder{
+						Name: "stderr",
+						Max:  4096,
+					}.Build(),
+				}.Build(),
+			},
+			CpuTimeLimit:   uint64(10 * time.Second),
+			ClockTimeLimit: uint64(20 * time.Second),
+			MemoryLimit:    memoryLimit,
+			ProcLimit:      1,
+			CopyIn:         copyIn,
+			CopyOut:        []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stdout"}.Build(), pb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()},
+		}.Build()},
+	}.Build())
+	if err != nil {
+		return false, "", err
+	}
+	if ret.GetError() != "" {
+		return false, "", fmt.Errorf("checker %v", ret.GetError())
+	}
+	r := ret.GetResults()[0]
+	log := string(r.GetFiles()["stderr"])
+	switch r.GetStatus() {
+	case pb.Response_Result_Accepted:
+		return true, log, nil
+	case pb.Response_Result_NonZeroExitStatus:
+		return false, log, nil
+	default:
+		return false, log, fmt.Errorf("checker %v", r.GetStatus())
+	}
+}
+
+func (j *judger) deleteFiles(fileIDs map[string]string) {
+	for _, fid := range fileIDs {
+		j.execClient.FileDelete(context.TODO(), pb.FileID_builder{
+			FileID: fid,
+		}.Build())
+	}
+}
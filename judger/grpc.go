@@ -19,15 +19,17 @@ import (
 type judger struct {
 	execClient pb.ExecutorClient
 	demoClient demopb.DemoBackendClient
+	blob       *blobCache
 
 	request  chan *demopb.JudgeClientRequest
 	response chan *demopb.JudgeClientResponse
 }
 
-func newJudger(execClient pb.ExecutorClient, demoClient demopb.DemoBackendClient) *judger {
+func newJudger(execClient pb.ExecutorClient, demoClient demopb.DemoBackendClient, blob *blobCache) *judger {
 	return &judger{
 		execClient: execClient,
 		demoClient: demoClient,
+		blob:       blob,
 
 		request:  make(chan *demopb.JudgeClientRequest, 64),
 		response: make(chan *demopb.JudgeClientResponse, 64),
@@ -92,10 +94,26 @@ func (j *judger) reportLoop() error {
 func (j *judger) judgeLoop() {
 	for {
 		req := <-j.request
+		if req.HasBuildProblem() {
+			j.buildSingle(req)
+			continue
+		}
 		j.judgeSingle(req)
 	}
 }
 
+// java, go, node needs more threads.. need a better way
+// may be add cpu bandwidth on cgroup..
+func languageProcLimit(name string) uint64 {
+	switch name {
+	case "java":
+		return 25
+	case "go", "javascript", "typescript", "ruby", "csharp", "perl":
+		return 12
+	}
+	return 1
+}
+
 func judgeClientResponse(id string, t string, status string) *demopb.JudgeClientResponse {
 	return demopb.JudgeClientResponse_builder{
 		Id:     &id,
@@ -122,6 +140,21 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 		copyOut = append(copyOut, pb.Request_CmdCopyOutFile_builder{Name: f}.Build())
 	}
 
+	compileCopyIn := map[string]*pb.Request_File{
+		req.GetLanguage().GetSourceFileName(): pb.Request_File_builder{
+			Memory: pb.Request_MemoryFile_builder{
+				Content: []byte(req.GetSource()),
+			}.Build(),
+		}.Build(),
+	}
+	for _, f := range req.GetFiles() {
+		compileCopyIn[f.GetName()] = pb.Request_File_builder{
+			Memory: pb.Request_MemoryFile_builder{
+				Content: f.GetContent(),
+			}.Build(),
+		}.Build()
+	}
+
 	compileReq := pb.Request_builder{
 		Cmd: []*pb.Request_CmdType{pb.Request_CmdType_builder{
 			Args: args,
@@ -149,15 +182,9 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 			ClockTimeLimit: uint64(12 * time.Second),
 			MemoryLimit:    memoryLimit,
 			ProcLimit:      100,
-			CopyIn: map[string]*pb.Request_File{
-				req.GetLanguage().GetSourceFileName(): pb.Request_File_builder{
-					Memory: pb.Request_MemoryFile_builder{
-						Content: []byte(req.GetSource()),
-					}.Build(),
-				}.Build(),
-			},
-			CopyOut:       []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stdout"}.Build(), pb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()},
-			CopyOutCached: copyOut,
+			CopyIn:         compileCopyIn,
+			CopyOut:        []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stdout"}.Build(), pb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()},
+			CopyOutCached:  copyOut,
 		}.Build()},
 	}.Build()
 	compileRet, err := j.execClient.Exec(context.TODO(), compileReq)
@@ -183,13 +210,7 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 	}.Build())
 
 	// remove exec file
-	defer func() {
-		for _, fid := range cRet.GetFileIDs() {
-			j.execClient.FileDelete(context.TODO(), pb.FileID_builder{
-				FileID: fid,
-			}.Build())
-		}
-	}()
+	defer j.deleteFiles(cRet.GetFileIDs())
 
 	if cRet.GetStatus() != pb.Response_Result_Accepted {
 		rt := judgeClientResponse(req.GetId(), "finished", fmt.Sprintf("Compile %v %v", cRet.GetStatus().String(), compileRet.GetError()))
@@ -200,6 +221,25 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 
 	j.response <- judgeClientResponse(req.GetId(), "progress", "Compiled")
 
+	var checkerFileIDs map[string]string
+	if req.GetChecker().GetSource() != "" {
+		checkerFileIDs, err = j.compileChecker(context.TODO(), req.GetChecker())
+		if err != nil {
+			j.response <- judgeClientResponse(req.GetId(), "finished", fmt.Sprintf("Checker Compile Error %v", err))
+			return
+		}
+		defer j.deleteFiles(checkerFileIDs)
+	}
+
+	cpuTimeLimit := uint64(3 * time.Second)
+	if req.GetTimeLimit() > 0 {
+		cpuTimeLimit = uint64(time.Duration(req.GetTimeLimit()) * time.Millisecond)
+	}
+	runMemoryLimit := uint64(memoryLimit)
+	if req.GetMemoryLimit() > 0 {
+		runMemoryLimit = req.GetMemoryLimit() << 10
+	}
+
 	var completed int32
 
 	io := req.GetInputAnswer()
@@ -222,17 +262,11 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 			if err != nil {
 				return err
 			}
-			input := inputOutput.GetInput()
-			ansContent := inputOutput.GetAnswer()
-			// java, go, node needs more threads.. need a better way
-			// may be add cpu bandwidth on cgroup..
-			var procLimit uint64 = 1
-			switch req.GetLanguage().GetName() {
-			case "java":
-				procLimit = 25
-			case "go", "javascript", "typescript", "ruby", "csharp", "perl":
-				procLimit = 12
+			input, ansContent, err := j.testData(context.TODO(), inputOutput)
+			if err != nil {
+				return err
 			}
+			procLimit := languageProcLimit(req.GetLanguage().GetName())
 			copyin := make(map[string]*pb.Request_File)
 			for k, v := range cRet.GetFileIDs() {
 				copyin[k] = pb.Request_File_builder{
@@ -264,10 +298,10 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 							}.Build(),
 						}.Build(),
 					},
-					CpuTimeLimit:   uint64(3 * time.Second),
-					ClockTimeLimit: uint64(6 * time.Second),
-					MemoryLimit:    memoryLimit,
-					StackLimit:     memoryLimit,
+					CpuTimeLimit:   cpuTimeLimit,
+					ClockTimeLimit: 2 * cpuTimeLimit,
+					MemoryLimit:    runMemoryLimit,
+					StackLimit:     runMemoryLimit,
 					ProcLimit:      procLimit,
 					CopyIn:         copyin,
 					CopyOut:        []*pb.Request_CmdCopyOutFile{pb.Request_CmdCopyOutFile_builder{Name: "stdout"}.Build(), pb.Request_CmdCopyOutFile_builder{Name: "stderr"}.Build()},
@@ -281,10 +315,24 @@ func (j *judger) judgeSingle(req *demopb.JudgeClientRequest) {
 				return fmt.Errorf("case %d %v", i, response.GetError())
 			}
 			ret := response.GetResults()[0]
-			err = diff.Compare(bytes.NewBufferString(ansContent), bytes.NewBuffer(ret.GetFiles()["stdout"]))
-			if err != nil && ret.GetStatus() == pb.Response_Result_Accepted {
-				ret.SetStatus(pb.Response_Result_WrongAnswer)
-				runResult[i].SetLog(err.Error())
+			if checkerFileIDs != nil {
+				if ret.GetStatus() == pb.Response_Result_Accepted {
+					ok, log, err := j.runChecker(context.TODO(), req.GetChecker(), checkerFileIDs,
+						[]byte(input), ret.GetFiles()["stdout"], []byte(ansContent))
+					if err != nil {
+						return err
+					}
+					if !ok {
+						ret.SetStatus(pb.Response_Result_WrongAnswer)
+					}
+					runResult[i].SetLog(log)
+				}
+			} else {
+				err = diff.Compare(bytes.NewBufferString(ansContent), bytes.NewBuffer(ret.GetFiles()["stdout"]))
+				if err != nil && ret.GetStatus() == pb.Response_Result_Accepted {
+					ret.SetStatus(pb.Response_Result_WrongAnswer)
+					runResult[i].SetLog(err.Error())
+				}
 			}
 			runResult[i].SetTime(ret.GetTime() / 1e6)
 			runResult[i].SetMemory(ret.GetMemory() >> 10)
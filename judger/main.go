@@ -8,8 +8,10 @@ import (
 	_ "net/http/pprof" // for pprof
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
+	"github.com/criyle/go-judge-demo/internal/tlsfile"
 	demopb "github.com/criyle/go-judge-demo/pb"
 	execpb "github.com/criyle/go-judge/pb"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
@@ -21,7 +23,6 @@ import (
 	"go.uber.org/zap/zapgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
 )
 
@@ -30,9 +31,17 @@ const (
 	envExecServerURL = "EXEC_SERVER"
 	envRelease       = "RELEASE"
 	envToken         = "TOKEN"
+	envBlobCacheDir  = "BLOB_CACHE_DIR"
+	envBlobCacheSize = "BLOB_CACHE_SIZE"
+	envTLSCert       = "TLS_CERT"
+	envTLSKey        = "TLS_KEY"
+	envTLSCA         = "TLS_CA"
+	envTLSServerName = "TLS_SERVER_NAME"
 
 	defaultDemoServerURL = "localhost:5081"
 	defaultExecServerURL = "localhost:5051"
+	defaultBlobCacheDir  = "blob"
+	defaultBlobCacheSize = 1 << 30 // 1g
 )
 
 const (
@@ -90,19 +99,39 @@ func main() {
 	}()
 
 	token := os.Getenv(envToken)
+	tf, err := tlsfile.New(os.Getenv(envTLSCert), os.Getenv(envTLSKey), os.Getenv(envTLSCA))
+	if err != nil {
+		log.Fatalln("tls", err)
+	}
 	execServer := defaultExecServerURL
 	if e := os.Getenv(envExecServerURL); e != "" {
 		execServer = e
 	}
-	execClient := createExecClient(execServer, token)
+	execClient := createExecClient(execServer, token, tf)
 
 	demoServer := defaultDemoServerURL
 	if e := os.Getenv(envDemoServerURL); e != "" {
 		demoServer = e
 	}
-	demoClient := createDemoClient(demoServer, token)
+	demoClient := createDemoClient(demoServer, token, tf)
+
+	blobCacheDir := defaultBlobCacheDir
+	if e := os.Getenv(envBlobCacheDir); e != "" {
+		blobCacheDir = e
+	}
+	var blobCacheSize int64 = defaultBlobCacheSize
+	if e := os.Getenv(envBlobCacheSize); e != "" {
+		blobCacheSize, err = strconv.ParseInt(e, 10, 64)
+		if err != nil {
+			log.Fatalln("blob cache size", err)
+		}
+	}
+	blob, err := newBlobCache(blobCacheDir, blobCacheSize, demoClient)
+	if err != nil {
+		log.Fatalln("blob cache", err)
+	}
 
-	j := newJudger(execClient, demoClient)
+	j := newJudger(execClient, demoClient, blob)
 	j.Start()
 
 	sig := make(chan os.Signal, 1)
@@ -112,16 +141,16 @@ func main() {
 	log.Println("interrupted")
 }
 
-func createExecClient(execServer, token string) execpb.ExecutorClient {
-	conn, err := createGRPCConnection(execServer, token)
+func createExecClient(execServer, token string, tf *tlsfile.Files) execpb.ExecutorClient {
+	conn, err := createGRPCConnection(execServer, token, tf)
 	if err != nil {
 		log.Fatalln("client", err)
 	}
 	return execpb.NewExecutorClient(conn)
 }
 
-func createDemoClient(execServer, token string) demopb.DemoBackendClient {
-	conn, err := createGRPCConnection(execServer, token)
+func createDemoClient(execServer, token string, tf *tlsfile.Files) demopb.DemoBackendClient {
+	conn, err := createGRPCConnection(execServer, token, tf)
 	if err != nil {
 		log.Fatalln("client", err)
 	}
@@ -165,10 +194,10 @@ func InterceptorLogger(l *zap.Logger) logging.Logger {
 	})
 }
 
-func createGRPCConnection(addr, token string) (*grpc.ClientConn, error) {
+func createGRPCConnection(addr, token string, tf *tlsfile.Files) (*grpc.ClientConn, error) {
 	grpclog.SetLoggerV2(zapgrpc.NewLogger(logger))
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(tlsfile.DialCredentials(tf, os.Getenv(envTLSServerName))),
 		grpc.WithChainUnaryInterceptor(
 			prom.UnaryClientInterceptor(),
 			logging.UnaryClientInterceptor(InterceptorLogger(logger)),
@@ -178,17 +207,18 @@ func createGRPCConnection(addr, token string) (*grpc.ClientConn, error) {
 			logging.StreamClientInterceptor(InterceptorLogger(logger)),
 		)}
 	if token != "" {
-		opts = append(opts, grpc.WithPerRPCCredentials(newTokenAuth(token)))
+		opts = append(opts, grpc.WithPerRPCCredentials(newTokenAuth(token, tf != nil)))
 	}
 	return grpc.NewClient(addr, opts...)
 }
 
 type tokenAuth struct {
-	token string
+	token  string
+	secure bool
 }
 
-func newTokenAuth(token string) credentials.PerRPCCredentials {
-	return &tokenAuth{token: token}
+func newTokenAuth(token string, secure bool) credentials.PerRPCCredentials {
+	return &tokenAuth{token: token, secure: secure}
 }
 
 // Return value is mapped to request headers.
@@ -198,6 +228,6 @@ func (t *tokenAuth) GetRequestMetadata(ctx context.Context, in ...string) (map[s
 	}, nil
 }
 
-func (*tokenAuth) RequireTransportSecurity() bool {
-	return false
+func (t *tokenAuth) RequireTransportSecurity() bool {
+	return t.secure
 }
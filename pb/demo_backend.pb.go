@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.4
+// 	protoc-gen-go v1.36.11
 // 	protoc        v5.29.3
 // source: demo_backend.proto
 
@@ -10,7 +10,6 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	_ "google.golang.org/protobuf/types/gofeaturespb"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
@@ -23,6 +22,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Priority is the scheduling class of a judge request, higher classes are
+// dispatched first and requests within a class are shared fairly among users
+type Priority int32
+
+const (
+	Priority_PRIORITY_DEFAULT     Priority = 0 // interactive for custom input, contest for problem
+	Priority_PRIORITY_INTERACTIVE Priority = 1 // interactive run with custom input
+	Priority_PRIORITY_CONTEST     Priority = 2 // problem submission
+	Priority_PRIORITY_REJUDGE     Priority = 3
+	Priority_PRIORITY_BULK        Priority = 4 // e.g. test data build
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_DEFAULT",
+		1: "PRIORITY_INTERACTIVE",
+		2: "PRIORITY_CONTEST",
+		3: "PRIORITY_REJUDGE",
+		4: "PRIORITY_BULK",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_DEFAULT":     0,
+		"PRIORITY_INTERACTIVE": 1,
+		"PRIORITY_CONTEST":     2,
+		"PRIORITY_REJUDGE":     3,
+		"PRIORITY_BULK":        4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_demo_backend_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_demo_backend_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type SubmissionRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
@@ -160,6 +211,84 @@ func (b0 SubmissionResponse_builder) Build() *SubmissionResponse {
 	return m0
 }
 
+type GetSubmissionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetSubmissionRequest) Reset() {
+	*x = GetSubmissionRequest{}
+	mi := &file_demo_backend_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionRequest) ProtoMessage() {}
+
+func (x *GetSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetSubmissionRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetSubmissionRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *GetSubmissionRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetSubmissionRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type GetSubmissionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 GetSubmissionRequest_builder) Build() *GetSubmissionRequest {
+	m0 := &GetSubmissionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
 type Submission struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
@@ -170,6 +299,11 @@ type Submission struct {
 	xxx_hidden_TotalTime   uint64                 `protobuf:"varint,6,opt,name=totalTime"`
 	xxx_hidden_MaxMemory   uint64                 `protobuf:"varint,7,opt,name=maxMemory"`
 	xxx_hidden_Results     *[]*Result             `protobuf:"bytes,8,rep,name=results"`
+	xxx_hidden_Files       *[]*SourceFile         `protobuf:"bytes,9,rep,name=files"`
+	xxx_hidden_ProblemId   *string                `protobuf:"bytes,10,opt,name=problemId"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,11,opt,name=userId"`
+	xxx_hidden_UserName    *string                `protobuf:"bytes,12,opt,name=userName"`
+	xxx_hidden_Type        *string                `protobuf:"bytes,13,opt,name=type"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -178,7 +312,7 @@ type Submission struct {
 
 func (x *Submission) Reset() {
 	*x = Submission{}
-	mi := &file_demo_backend_proto_msgTypes[2]
+	mi := &file_demo_backend_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -190,7 +324,7 @@ func (x *Submission) String() string {
 func (*Submission) ProtoMessage() {}
 
 func (x *Submission) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[2]
+	mi := &file_demo_backend_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,9 +402,58 @@ func (x *Submission) GetResults() []*Result {
 	return nil
 }
 
+func (x *Submission) GetFiles() []*SourceFile {
+	if x != nil {
+		if x.xxx_hidden_Files != nil {
+			return *x.xxx_hidden_Files
+		}
+	}
+	return nil
+}
+
+func (x *Submission) GetProblemId() string {
+	if x != nil {
+		if x.xxx_hidden_ProblemId != nil {
+			return *x.xxx_hidden_ProblemId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Submission) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Submission) GetUserName() string {
+	if x != nil {
+		if x.xxx_hidden_UserName != nil {
+			return *x.xxx_hidden_UserName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Submission) GetType() string {
+	if x != nil {
+		if x.xxx_hidden_Type != nil {
+			return *x.xxx_hidden_Type
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *Submission) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 13)
 }
 
 func (x *Submission) SetLanguage(v *Language) {
@@ -279,7 +462,7 @@ func (x *Submission) SetLanguage(v *Language) {
 
 func (x *Submission) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 13)
 }
 
 func (x *Submission) SetDate(v *timestamppb.Timestamp) {
@@ -288,23 +471,47 @@ func (x *Submission) SetDate(v *timestamppb.Timestamp) {
 
 func (x *Submission) SetStatus(v string) {
 	x.xxx_hidden_Status = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 13)
 }
 
 func (x *Submission) SetTotalTime(v uint64) {
 	x.xxx_hidden_TotalTime = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 13)
 }
 
 func (x *Submission) SetMaxMemory(v uint64) {
 	x.xxx_hidden_MaxMemory = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 13)
 }
 
 func (x *Submission) SetResults(v []*Result) {
 	x.xxx_hidden_Results = &v
 }
 
+func (x *Submission) SetFiles(v []*SourceFile) {
+	x.xxx_hidden_Files = &v
+}
+
+func (x *Submission) SetProblemId(v string) {
+	x.xxx_hidden_ProblemId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 13)
+}
+
+func (x *Submission) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 13)
+}
+
+func (x *Submission) SetUserName(v string) {
+	x.xxx_hidden_UserName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 13)
+}
+
+func (x *Submission) SetType(v string) {
+	x.xxx_hidden_Type = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 13)
+}
+
 func (x *Submission) HasId() bool {
 	if x == nil {
 		return false
@@ -354,6 +561,34 @@ func (x *Submission) HasMaxMemory() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
+func (x *Submission) HasProblemId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *Submission) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *Submission) HasUserName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *Submission) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
 func (x *Submission) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = nil
@@ -387,6 +622,26 @@ func (x *Submission) ClearMaxMemory() {
 	x.xxx_hidden_MaxMemory = 0
 }
 
+func (x *Submission) ClearProblemId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_ProblemId = nil
+}
+
+func (x *Submission) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_UserId = nil
+}
+
+func (x *Submission) ClearUserName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_UserName = nil
+}
+
+func (x *Submission) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_Type = nil
+}
+
 type Submission_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -398,6 +653,11 @@ type Submission_builder struct {
 	TotalTime *uint64
 	MaxMemory *uint64
 	Results   []*Result
+	Files     []*SourceFile
+	ProblemId *string
+	UserId    *string
+	UserName  *string
+	Type      *string
 }
 
 func (b0 Submission_builder) Build() *Submission {
@@ -405,28 +665,45 @@ func (b0 Submission_builder) Build() *Submission {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 13)
 		x.xxx_hidden_Id = b.Id
 	}
 	x.xxx_hidden_Language = b.Language
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 13)
 		x.xxx_hidden_Source = b.Source
 	}
 	x.xxx_hidden_Date = b.Date
 	if b.Status != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 13)
 		x.xxx_hidden_Status = b.Status
 	}
 	if b.TotalTime != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 13)
 		x.xxx_hidden_TotalTime = *b.TotalTime
 	}
 	if b.MaxMemory != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 13)
 		x.xxx_hidden_MaxMemory = *b.MaxMemory
 	}
 	x.xxx_hidden_Results = &b.Results
+	x.xxx_hidden_Files = &b.Files
+	if b.ProblemId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 13)
+		x.xxx_hidden_ProblemId = b.ProblemId
+	}
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 13)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	if b.UserName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 13)
+		x.xxx_hidden_UserName = b.UserName
+	}
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 13)
+		x.xxx_hidden_Type = b.Type
+	}
 	return m0
 }
 
@@ -445,7 +722,7 @@ type Language struct {
 
 func (x *Language) Reset() {
 	*x = Language{}
-	mi := &file_demo_backend_proto_msgTypes[3]
+	mi := &file_demo_backend_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -457,7 +734,7 @@ func (x *Language) String() string {
 func (*Language) ProtoMessage() {}
 
 func (x *Language) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[3]
+	mi := &file_demo_backend_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,7 +933,7 @@ type Result struct {
 
 func (x *Result) Reset() {
 	*x = Result{}
-	mi := &file_demo_backend_proto_msgTypes[4]
+	mi := &file_demo_backend_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -668,7 +945,7 @@ func (x *Result) String() string {
 func (*Result) ProtoMessage() {}
 
 func (x *Result) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[4]
+	mi := &file_demo_backend_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -881,6 +1158,8 @@ type InputAnswer struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Input       *string                `protobuf:"bytes,1,opt,name=input"`
 	xxx_hidden_Answer      *string                `protobuf:"bytes,2,opt,name=answer"`
+	xxx_hidden_InputHash   *string                `protobuf:"bytes,3,opt,name=inputHash"`
+	xxx_hidden_AnswerHash  *string                `protobuf:"bytes,4,opt,name=answerHash"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -889,7 +1168,7 @@ type InputAnswer struct {
 
 func (x *InputAnswer) Reset() {
 	*x = InputAnswer{}
-	mi := &file_demo_backend_proto_msgTypes[5]
+	mi := &file_demo_backend_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -901,7 +1180,7 @@ func (x *InputAnswer) String() string {
 func (*InputAnswer) ProtoMessage() {}
 
 func (x *InputAnswer) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[5]
+	mi := &file_demo_backend_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -932,14 +1211,44 @@ func (x *InputAnswer) GetAnswer() string {
 	return ""
 }
 
+func (x *InputAnswer) GetInputHash() string {
+	if x != nil {
+		if x.xxx_hidden_InputHash != nil {
+			return *x.xxx_hidden_InputHash
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *InputAnswer) GetAnswerHash() string {
+	if x != nil {
+		if x.xxx_hidden_AnswerHash != nil {
+			return *x.xxx_hidden_AnswerHash
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *InputAnswer) SetInput(v string) {
 	x.xxx_hidden_Input = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
 }
 
 func (x *InputAnswer) SetAnswer(v string) {
 	x.xxx_hidden_Answer = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *InputAnswer) SetInputHash(v string) {
+	x.xxx_hidden_InputHash = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *InputAnswer) SetAnswerHash(v string) {
+	x.xxx_hidden_AnswerHash = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
 }
 
 func (x *InputAnswer) HasInput() bool {
@@ -956,6 +1265,20 @@ func (x *InputAnswer) HasAnswer() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *InputAnswer) HasInputHash() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *InputAnswer) HasAnswerHash() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
 func (x *InputAnswer) ClearInput() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Input = nil
@@ -966,11 +1289,23 @@ func (x *InputAnswer) ClearAnswer() {
 	x.xxx_hidden_Answer = nil
 }
 
+func (x *InputAnswer) ClearInputHash() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_InputHash = nil
+}
+
+func (x *InputAnswer) ClearAnswerHash() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_AnswerHash = nil
+}
+
 type InputAnswer_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Input  *string
-	Answer *string
+	Input      *string
+	Answer     *string
+	InputHash  *string
+	AnswerHash *string
 }
 
 func (b0 InputAnswer_builder) Build() *InputAnswer {
@@ -978,42 +1313,49 @@ func (b0 InputAnswer_builder) Build() *InputAnswer {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Input != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
 		x.xxx_hidden_Input = b.Input
 	}
 	if b.Answer != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
 		x.xxx_hidden_Answer = b.Answer
 	}
+	if b.InputHash != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_InputHash = b.InputHash
+	}
+	if b.AnswerHash != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_AnswerHash = b.AnswerHash
+	}
 	return m0
 }
 
-type SubmitRequest struct {
+type SourceFile struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Language    *Language              `protobuf:"bytes,1,opt,name=language"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,2,opt,name=source"`
-	xxx_hidden_InputAnswer *[]*InputAnswer        `protobuf:"bytes,3,rep,name=inputAnswer"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,2,opt,name=content"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *SubmitRequest) Reset() {
-	*x = SubmitRequest{}
-	mi := &file_demo_backend_proto_msgTypes[6]
+func (x *SourceFile) Reset() {
+	*x = SourceFile{}
+	mi := &file_demo_backend_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitRequest) String() string {
+func (x *SourceFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitRequest) ProtoMessage() {}
+func (*SourceFile) ProtoMessage() {}
 
-func (x *SubmitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[6]
+func (x *SourceFile) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1024,17 +1366,132 @@ func (x *SubmitRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *SubmitRequest) GetLanguage() *Language {
-	if x != nil {
-		return x.xxx_hidden_Language
-	}
-	return nil
-}
-
-func (x *SubmitRequest) GetSource() string {
+func (x *SourceFile) GetName() string {
 	if x != nil {
-		if x.xxx_hidden_Source != nil {
-			return *x.xxx_hidden_Source
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SourceFile) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *SourceFile) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *SourceFile) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *SourceFile) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SourceFile) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SourceFile) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *SourceFile) ClearContent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Content = nil
+}
+
+type SourceFile_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name    *string
+	Content []byte
+}
+
+func (b0 SourceFile_builder) Build() *SourceFile {
+	m0 := &SourceFile{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Content != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Content = b.Content
+	}
+	return m0
+}
+
+type SubmitRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Language    *Language              `protobuf:"bytes,1,opt,name=language"`
+	xxx_hidden_Source      *string                `protobuf:"bytes,2,opt,name=source"`
+	xxx_hidden_InputAnswer *[]*InputAnswer        `protobuf:"bytes,3,rep,name=inputAnswer"`
+	xxx_hidden_Files       *[]*SourceFile         `protobuf:"bytes,4,rep,name=files"`
+	xxx_hidden_ProblemId   *string                `protobuf:"bytes,5,opt,name=problemId"`
+	xxx_hidden_Priority    Priority               `protobuf:"varint,6,opt,name=priority,enum=pb.Priority"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SubmitRequest) Reset() {
+	*x = SubmitRequest{}
+	mi := &file_demo_backend_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRequest) ProtoMessage() {}
+
+func (x *SubmitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SubmitRequest) GetLanguage() *Language {
+	if x != nil {
+		return x.xxx_hidden_Language
+	}
+	return nil
+}
+
+func (x *SubmitRequest) GetSource() string {
+	if x != nil {
+		if x.xxx_hidden_Source != nil {
+			return *x.xxx_hidden_Source
 		}
 		return ""
 	}
@@ -1050,19 +1507,61 @@ func (x *SubmitRequest) GetInputAnswer() []*InputAnswer {
 	return nil
 }
 
+func (x *SubmitRequest) GetFiles() []*SourceFile {
+	if x != nil {
+		if x.xxx_hidden_Files != nil {
+			return *x.xxx_hidden_Files
+		}
+	}
+	return nil
+}
+
+func (x *SubmitRequest) GetProblemId() string {
+	if x != nil {
+		if x.xxx_hidden_ProblemId != nil {
+			return *x.xxx_hidden_ProblemId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SubmitRequest) GetPriority() Priority {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 5) {
+			return x.xxx_hidden_Priority
+		}
+	}
+	return Priority_PRIORITY_DEFAULT
+}
+
 func (x *SubmitRequest) SetLanguage(v *Language) {
 	x.xxx_hidden_Language = v
 }
 
 func (x *SubmitRequest) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
 }
 
 func (x *SubmitRequest) SetInputAnswer(v []*InputAnswer) {
 	x.xxx_hidden_InputAnswer = &v
 }
 
+func (x *SubmitRequest) SetFiles(v []*SourceFile) {
+	x.xxx_hidden_Files = &v
+}
+
+func (x *SubmitRequest) SetProblemId(v string) {
+	x.xxx_hidden_ProblemId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *SubmitRequest) SetPriority(v Priority) {
+	x.xxx_hidden_Priority = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
 func (x *SubmitRequest) HasLanguage() bool {
 	if x == nil {
 		return false
@@ -1077,6 +1576,20 @@ func (x *SubmitRequest) HasSource() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *SubmitRequest) HasProblemId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SubmitRequest) HasPriority() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
 func (x *SubmitRequest) ClearLanguage() {
 	x.xxx_hidden_Language = nil
 }
@@ -1086,12 +1599,25 @@ func (x *SubmitRequest) ClearSource() {
 	x.xxx_hidden_Source = nil
 }
 
+func (x *SubmitRequest) ClearProblemId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ProblemId = nil
+}
+
+func (x *SubmitRequest) ClearPriority() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Priority = Priority_PRIORITY_DEFAULT
+}
+
 type SubmitRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Language    *Language
 	Source      *string
 	InputAnswer []*InputAnswer
+	Files       []*SourceFile
+	ProblemId   *string
+	Priority    *Priority
 }
 
 func (b0 SubmitRequest_builder) Build() *SubmitRequest {
@@ -1100,37 +1626,48 @@ func (b0 SubmitRequest_builder) Build() *SubmitRequest {
 	_, _ = b, x
 	x.xxx_hidden_Language = b.Language
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
 		x.xxx_hidden_Source = b.Source
 	}
 	x.xxx_hidden_InputAnswer = &b.InputAnswer
+	x.xxx_hidden_Files = &b.Files
+	if b.ProblemId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_ProblemId = b.ProblemId
+	}
+	if b.Priority != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Priority = *b.Priority
+	}
 	return m0
 }
 
-type SubmitResponse struct {
+// RunRequest submits and waits for the finished update
+type RunRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Submit      *SubmitRequest         `protobuf:"bytes,1,opt,name=submit"`
+	xxx_hidden_Timeout     uint32                 `protobuf:"varint,2,opt,name=timeout"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *SubmitResponse) Reset() {
-	*x = SubmitResponse{}
-	mi := &file_demo_backend_proto_msgTypes[7]
+func (x *RunRequest) Reset() {
+	*x = RunRequest{}
+	mi := &file_demo_backend_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitResponse) String() string {
+func (x *RunRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitResponse) ProtoMessage() {}
+func (*RunRequest) ProtoMessage() {}
 
-func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[7]
+func (x *RunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1141,80 +1678,96 @@ func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *SubmitResponse) GetId() string {
+func (x *RunRequest) GetSubmit() *SubmitRequest {
 	if x != nil {
-		if x.xxx_hidden_Id != nil {
-			return *x.xxx_hidden_Id
-		}
-		return ""
+		return x.xxx_hidden_Submit
 	}
-	return ""
+	return nil
 }
 
-func (x *SubmitResponse) SetId(v string) {
-	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+func (x *RunRequest) GetTimeout() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Timeout
+	}
+	return 0
 }
 
-func (x *SubmitResponse) HasId() bool {
+func (x *RunRequest) SetSubmit(v *SubmitRequest) {
+	x.xxx_hidden_Submit = v
+}
+
+func (x *RunRequest) SetTimeout(v uint32) {
+	x.xxx_hidden_Timeout = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RunRequest) HasSubmit() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return x.xxx_hidden_Submit != nil
 }
 
-func (x *SubmitResponse) ClearId() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Id = nil
+func (x *RunRequest) HasTimeout() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-type SubmitResponse_builder struct {
+func (x *RunRequest) ClearSubmit() {
+	x.xxx_hidden_Submit = nil
+}
+
+func (x *RunRequest) ClearTimeout() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Timeout = 0
+}
+
+type RunRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id *string
+	Submit  *SubmitRequest
+	Timeout *uint32
 }
 
-func (b0 SubmitResponse_builder) Build() *SubmitResponse {
-	m0 := &SubmitResponse{}
+func (b0 RunRequest_builder) Build() *RunRequest {
+	m0 := &RunRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Submit = b.Submit
+	if b.Timeout != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Timeout = *b.Timeout
 	}
 	return m0
 }
 
-type JudgeUpdate struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
-	xxx_hidden_Type        *string                `protobuf:"bytes,2,opt,name=type"`
-	xxx_hidden_Status      *string                `protobuf:"bytes,3,opt,name=status"`
-	xxx_hidden_Date        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date"`
-	xxx_hidden_Language    *Language              `protobuf:"bytes,5,opt,name=language"`
-	xxx_hidden_Results     *[]*Result             `protobuf:"bytes,6,rep,name=results"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,7,opt,name=source"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+type SubmitResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id            *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_QueuePosition uint32                 `protobuf:"varint,2,opt,name=queuePosition"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
-func (x *JudgeUpdate) Reset() {
-	*x = JudgeUpdate{}
-	mi := &file_demo_backend_proto_msgTypes[8]
+func (x *SubmitResponse) Reset() {
+	*x = SubmitResponse{}
+	mi := &file_demo_backend_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JudgeUpdate) String() string {
+func (x *SubmitResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JudgeUpdate) ProtoMessage() {}
+func (*SubmitResponse) ProtoMessage() {}
 
-func (x *JudgeUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[8]
+func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1225,7 +1778,7 @@ func (x *JudgeUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *JudgeUpdate) GetId() string {
+func (x *SubmitResponse) GetId() string {
 	if x != nil {
 		if x.xxx_hidden_Id != nil {
 			return *x.xxx_hidden_Id
@@ -1235,24 +1788,140 @@ func (x *JudgeUpdate) GetId() string {
 	return ""
 }
 
-func (x *JudgeUpdate) GetType() string {
+func (x *SubmitResponse) GetQueuePosition() uint32 {
 	if x != nil {
-		if x.xxx_hidden_Type != nil {
-			return *x.xxx_hidden_Type
-		}
-		return ""
+		return x.xxx_hidden_QueuePosition
 	}
-	return ""
+	return 0
 }
 
-func (x *JudgeUpdate) GetStatus() string {
-	if x != nil {
-		if x.xxx_hidden_Status != nil {
-			return *x.xxx_hidden_Status
-		}
-		return ""
-	}
-	return ""
+func (x *SubmitResponse) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *SubmitResponse) SetQueuePosition(v uint32) {
+	x.xxx_hidden_QueuePosition = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *SubmitResponse) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SubmitResponse) HasQueuePosition() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SubmitResponse) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *SubmitResponse) ClearQueuePosition() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_QueuePosition = 0
+}
+
+type SubmitResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id            *string
+	QueuePosition *uint32
+}
+
+func (b0 SubmitResponse_builder) Build() *SubmitResponse {
+	m0 := &SubmitResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.QueuePosition != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_QueuePosition = *b.QueuePosition
+	}
+	return m0
+}
+
+type JudgeUpdate struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id            *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Type          *string                `protobuf:"bytes,2,opt,name=type"`
+	xxx_hidden_Status        *string                `protobuf:"bytes,3,opt,name=status"`
+	xxx_hidden_Date          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date"`
+	xxx_hidden_Language      *Language              `protobuf:"bytes,5,opt,name=language"`
+	xxx_hidden_Results       *[]*Result             `protobuf:"bytes,6,rep,name=results"`
+	xxx_hidden_Source        *string                `protobuf:"bytes,7,opt,name=source"`
+	xxx_hidden_QueuePosition uint32                 `protobuf:"varint,8,opt,name=queuePosition"`
+	xxx_hidden_UserId        *string                `protobuf:"bytes,9,opt,name=userId"`
+	xxx_hidden_Seq           uint64                 `protobuf:"varint,10,opt,name=seq"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *JudgeUpdate) Reset() {
+	*x = JudgeUpdate{}
+	mi := &file_demo_backend_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JudgeUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JudgeUpdate) ProtoMessage() {}
+
+func (x *JudgeUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *JudgeUpdate) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *JudgeUpdate) GetType() string {
+	if x != nil {
+		if x.xxx_hidden_Type != nil {
+			return *x.xxx_hidden_Type
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *JudgeUpdate) GetStatus() string {
+	if x != nil {
+		if x.xxx_hidden_Status != nil {
+			return *x.xxx_hidden_Status
+		}
+		return ""
+	}
+	return ""
 }
 
 func (x *JudgeUpdate) GetDate() *timestamppb.Timestamp {
@@ -1288,19 +1957,43 @@ func (x *JudgeUpdate) GetSource() string {
 	return ""
 }
 
+func (x *JudgeUpdate) GetQueuePosition() uint32 {
+	if x != nil {
+		return x.xxx_hidden_QueuePosition
+	}
+	return 0
+}
+
+func (x *JudgeUpdate) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *JudgeUpdate) GetSeq() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Seq
+	}
+	return 0
+}
+
 func (x *JudgeUpdate) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
 }
 
 func (x *JudgeUpdate) SetType(v string) {
 	x.xxx_hidden_Type = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
 }
 
 func (x *JudgeUpdate) SetStatus(v string) {
 	x.xxx_hidden_Status = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
 }
 
 func (x *JudgeUpdate) SetDate(v *timestamppb.Timestamp) {
@@ -1317,7 +2010,22 @@ func (x *JudgeUpdate) SetResults(v []*Result) {
 
 func (x *JudgeUpdate) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *JudgeUpdate) SetQueuePosition(v uint32) {
+	x.xxx_hidden_QueuePosition = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *JudgeUpdate) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *JudgeUpdate) SetSeq(v uint64) {
+	x.xxx_hidden_Seq = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
 }
 
 func (x *JudgeUpdate) HasId() bool {
@@ -1362,6 +2070,27 @@ func (x *JudgeUpdate) HasSource() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
+func (x *JudgeUpdate) HasQueuePosition() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *JudgeUpdate) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *JudgeUpdate) HasSeq() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
 func (x *JudgeUpdate) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = nil
@@ -1390,16 +2119,34 @@ func (x *JudgeUpdate) ClearSource() {
 	x.xxx_hidden_Source = nil
 }
 
+func (x *JudgeUpdate) ClearQueuePosition() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_QueuePosition = 0
+}
+
+func (x *JudgeUpdate) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_UserId = nil
+}
+
+func (x *JudgeUpdate) ClearSeq() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Seq = 0
+}
+
 type JudgeUpdate_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id       *string
-	Type     *string
-	Status   *string
-	Date     *timestamppb.Timestamp
-	Language *Language
-	Results  []*Result
-	Source   *string
+	Id            *string
+	Type          *string
+	Status        *string
+	Date          *timestamppb.Timestamp
+	Language      *Language
+	Results       []*Result
+	Source        *string
+	QueuePosition *uint32
+	UserId        *string
+	Seq           *uint64
 }
 
 func (b0 JudgeUpdate_builder) Build() *JudgeUpdate {
@@ -1407,54 +2154,67 @@ func (b0 JudgeUpdate_builder) Build() *JudgeUpdate {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
 		x.xxx_hidden_Id = b.Id
 	}
 	if b.Type != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
 		x.xxx_hidden_Type = b.Type
 	}
 	if b.Status != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
 		x.xxx_hidden_Status = b.Status
 	}
 	x.xxx_hidden_Date = b.Date
 	x.xxx_hidden_Language = b.Language
 	x.xxx_hidden_Results = &b.Results
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
 		x.xxx_hidden_Source = b.Source
 	}
+	if b.QueuePosition != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_QueuePosition = *b.QueuePosition
+	}
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	if b.Seq != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_Seq = *b.Seq
+	}
 	return m0
 }
 
-type JudgeClientRequest struct {
+// UpdatesRequest filters the updates, empty fields match all
+type UpdatesRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
-	xxx_hidden_Language    *Language              `protobuf:"bytes,2,opt,name=language"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,3,opt,name=source"`
-	xxx_hidden_InputAnswer *[]*InputAnswer        `protobuf:"bytes,4,rep,name=inputAnswer"`
+	xxx_hidden_Ids         []string               `protobuf:"bytes,1,rep,name=ids"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,2,opt,name=userId"`
+	xxx_hidden_Language    *string                `protobuf:"bytes,3,opt,name=language"`
+	xxx_hidden_Since       uint64                 `protobuf:"varint,4,opt,name=since"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *JudgeClientRequest) Reset() {
-	*x = JudgeClientRequest{}
-	mi := &file_demo_backend_proto_msgTypes[9]
+func (x *UpdatesRequest) Reset() {
+	*x = UpdatesRequest{}
+	mi := &file_demo_backend_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JudgeClientRequest) String() string {
+func (x *UpdatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JudgeClientRequest) ProtoMessage() {}
+func (*UpdatesRequest) ProtoMessage() {}
 
-func (x *JudgeClientRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[9]
+func (x *UpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1465,151 +2225,434 @@ func (x *JudgeClientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *JudgeClientRequest) GetId() string {
+func (x *UpdatesRequest) GetIds() []string {
 	if x != nil {
-		if x.xxx_hidden_Id != nil {
-			return *x.xxx_hidden_Id
-		}
-		return ""
+		return x.xxx_hidden_Ids
 	}
-	return ""
+	return nil
 }
 
-func (x *JudgeClientRequest) GetLanguage() *Language {
+func (x *UpdatesRequest) GetUserId() string {
 	if x != nil {
-		return x.xxx_hidden_Language
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *JudgeClientRequest) GetSource() string {
+func (x *UpdatesRequest) GetLanguage() string {
 	if x != nil {
-		if x.xxx_hidden_Source != nil {
-			return *x.xxx_hidden_Source
+		if x.xxx_hidden_Language != nil {
+			return *x.xxx_hidden_Language
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *JudgeClientRequest) GetInputAnswer() []*InputAnswer {
+func (x *UpdatesRequest) GetSince() uint64 {
 	if x != nil {
-		if x.xxx_hidden_InputAnswer != nil {
-			return *x.xxx_hidden_InputAnswer
-		}
+		return x.xxx_hidden_Since
 	}
-	return nil
+	return 0
 }
 
-func (x *JudgeClientRequest) SetId(v string) {
-	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+func (x *UpdatesRequest) SetIds(v []string) {
+	x.xxx_hidden_Ids = v
 }
 
-func (x *JudgeClientRequest) SetLanguage(v *Language) {
-	x.xxx_hidden_Language = v
+func (x *UpdatesRequest) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
 }
 
-func (x *JudgeClientRequest) SetSource(v string) {
-	x.xxx_hidden_Source = &v
+func (x *UpdatesRequest) SetLanguage(v string) {
+	x.xxx_hidden_Language = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
 }
 
-func (x *JudgeClientRequest) SetInputAnswer(v []*InputAnswer) {
-	x.xxx_hidden_InputAnswer = &v
+func (x *UpdatesRequest) SetSince(v uint64) {
+	x.xxx_hidden_Since = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
 }
 
-func (x *JudgeClientRequest) HasId() bool {
+func (x *UpdatesRequest) HasUserId() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *JudgeClientRequest) HasLanguage() bool {
+func (x *UpdatesRequest) HasLanguage() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Language != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *JudgeClientRequest) HasSource() bool {
+func (x *UpdatesRequest) HasSince() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *JudgeClientRequest) ClearId() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Id = nil
+func (x *UpdatesRequest) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_UserId = nil
 }
 
-func (x *JudgeClientRequest) ClearLanguage() {
+func (x *UpdatesRequest) ClearLanguage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
 	x.xxx_hidden_Language = nil
 }
 
-func (x *JudgeClientRequest) ClearSource() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Source = nil
+func (x *UpdatesRequest) ClearSince() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Since = 0
 }
 
-type JudgeClientRequest_builder struct {
+type UpdatesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id          *string
-	Language    *Language
-	Source      *string
-	InputAnswer []*InputAnswer
+	Ids      []string
+	UserId   *string
+	Language *string
+	Since    *uint64
 }
 
-func (b0 JudgeClientRequest_builder) Build() *JudgeClientRequest {
-	m0 := &JudgeClientRequest{}
+func (b0 UpdatesRequest_builder) Build() *UpdatesRequest {
+	m0 := &UpdatesRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
-		x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Ids = b.Ids
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_UserId = b.UserId
 	}
-	x.xxx_hidden_Language = b.Language
-	if b.Source != nil {
+	if b.Language != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
-		x.xxx_hidden_Source = b.Source
+		x.xxx_hidden_Language = b.Language
+	}
+	if b.Since != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Since = *b.Since
 	}
-	x.xxx_hidden_InputAnswer = &b.InputAnswer
 	return m0
 }
 
-type JudgeClientResponse struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
-	xxx_hidden_Type        *string                `protobuf:"bytes,2,opt,name=type"`
-	xxx_hidden_Status      *string                `protobuf:"bytes,3,opt,name=status"`
-	xxx_hidden_Date        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date"`
-	xxx_hidden_Language    *Language              `protobuf:"bytes,5,opt,name=language"`
-	xxx_hidden_Results     *[]*Result             `protobuf:"bytes,6,rep,name=results"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,7,opt,name=source"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+type JudgeClientRequest struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id           *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Language     *Language              `protobuf:"bytes,2,opt,name=language"`
+	xxx_hidden_Source       *string                `protobuf:"bytes,3,opt,name=source"`
+	xxx_hidden_InputAnswer  *[]*InputAnswer        `protobuf:"bytes,4,rep,name=inputAnswer"`
+	xxx_hidden_Files        *[]*SourceFile         `protobuf:"bytes,5,rep,name=files"`
+	xxx_hidden_TimeLimit    uint64                 `protobuf:"varint,6,opt,name=timeLimit"`
+	xxx_hidden_MemoryLimit  uint64                 `protobuf:"varint,7,opt,name=memoryLimit"`
+	xxx_hidden_Checker      *Checker               `protobuf:"bytes,8,opt,name=checker"`
+	xxx_hidden_BuildProblem *BuildProblemRequest   `protobuf:"bytes,9,opt,name=buildProblem"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
-func (x *JudgeClientResponse) Reset() {
-	*x = JudgeClientResponse{}
-	mi := &file_demo_backend_proto_msgTypes[10]
+func (x *JudgeClientRequest) Reset() {
+	*x = JudgeClientRequest{}
+	mi := &file_demo_backend_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JudgeClientResponse) String() string {
+func (x *JudgeClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JudgeClientRequest) ProtoMessage() {}
+
+func (x *JudgeClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *JudgeClientRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *JudgeClientRequest) GetLanguage() *Language {
+	if x != nil {
+		return x.xxx_hidden_Language
+	}
+	return nil
+}
+
+func (x *JudgeClientRequest) GetSource() string {
+	if x != nil {
+		if x.xxx_hidden_Source != nil {
+			return *x.xxx_hidden_Source
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *JudgeClientRequest) GetInputAnswer() []*InputAnswer {
+	if x != nil {
+		if x.xxx_hidden_InputAnswer != nil {
+			return *x.xxx_hidden_InputAnswer
+		}
+	}
+	return nil
+}
+
+func (x *JudgeClientRequest) GetFiles() []*SourceFile {
+	if x != nil {
+		if x.xxx_hidden_Files != nil {
+			return *x.xxx_hidden_Files
+		}
+	}
+	return nil
+}
+
+func (x *JudgeClientRequest) GetTimeLimit() uint64 {
+	if x != nil {
+		return x.xxx_hidden_TimeLimit
+	}
+	return 0
+}
+
+func (x *JudgeClientRequest) GetMemoryLimit() uint64 {
+	if x != nil {
+		return x.xxx_hidden_MemoryLimit
+	}
+	return 0
+}
+
+func (x *JudgeClientRequest) GetChecker() *Checker {
+	if x != nil {
+		return x.xxx_hidden_Checker
+	}
+	return nil
+}
+
+func (x *JudgeClientRequest) GetBuildProblem() *BuildProblemRequest {
+	if x != nil {
+		return x.xxx_hidden_BuildProblem
+	}
+	return nil
+}
+
+func (x *JudgeClientRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *JudgeClientRequest) SetLanguage(v *Language) {
+	x.xxx_hidden_Language = v
+}
+
+func (x *JudgeClientRequest) SetSource(v string) {
+	x.xxx_hidden_Source = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *JudgeClientRequest) SetInputAnswer(v []*InputAnswer) {
+	x.xxx_hidden_InputAnswer = &v
+}
+
+func (x *JudgeClientRequest) SetFiles(v []*SourceFile) {
+	x.xxx_hidden_Files = &v
+}
+
+func (x *JudgeClientRequest) SetTimeLimit(v uint64) {
+	x.xxx_hidden_TimeLimit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *JudgeClientRequest) SetMemoryLimit(v uint64) {
+	x.xxx_hidden_MemoryLimit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *JudgeClientRequest) SetChecker(v *Checker) {
+	x.xxx_hidden_Checker = v
+}
+
+func (x *JudgeClientRequest) SetBuildProblem(v *BuildProblemRequest) {
+	x.xxx_hidden_BuildProblem = v
+}
+
+func (x *JudgeClientRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *JudgeClientRequest) HasLanguage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Language != nil
+}
+
+func (x *JudgeClientRequest) HasSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *JudgeClientRequest) HasTimeLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *JudgeClientRequest) HasMemoryLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *JudgeClientRequest) HasChecker() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Checker != nil
+}
+
+func (x *JudgeClientRequest) HasBuildProblem() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_BuildProblem != nil
+}
+
+func (x *JudgeClientRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *JudgeClientRequest) ClearLanguage() {
+	x.xxx_hidden_Language = nil
+}
+
+func (x *JudgeClientRequest) ClearSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Source = nil
+}
+
+func (x *JudgeClientRequest) ClearTimeLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_TimeLimit = 0
+}
+
+func (x *JudgeClientRequest) ClearMemoryLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_MemoryLimit = 0
+}
+
+func (x *JudgeClientRequest) ClearChecker() {
+	x.xxx_hidden_Checker = nil
+}
+
+func (x *JudgeClientRequest) ClearBuildProblem() {
+	x.xxx_hidden_BuildProblem = nil
+}
+
+type JudgeClientRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id           *string
+	Language     *Language
+	Source       *string
+	InputAnswer  []*InputAnswer
+	Files        []*SourceFile
+	TimeLimit    *uint64
+	MemoryLimit  *uint64
+	Checker      *Checker
+	BuildProblem *BuildProblemRequest
+}
+
+func (b0 JudgeClientRequest_builder) Build() *JudgeClientRequest {
+	m0 := &JudgeClientRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_Id = b.Id
+	}
+	x.xxx_hidden_Language = b.Language
+	if b.Source != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Source = b.Source
+	}
+	x.xxx_hidden_InputAnswer = &b.InputAnswer
+	x.xxx_hidden_Files = &b.Files
+	if b.TimeLimit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_TimeLimit = *b.TimeLimit
+	}
+	if b.MemoryLimit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_MemoryLimit = *b.MemoryLimit
+	}
+	x.xxx_hidden_Checker = b.Checker
+	x.xxx_hidden_BuildProblem = b.BuildProblem
+	return m0
+}
+
+type JudgeClientResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Type        *string                `protobuf:"bytes,2,opt,name=type"`
+	xxx_hidden_Status      *string                `protobuf:"bytes,3,opt,name=status"`
+	xxx_hidden_Date        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date"`
+	xxx_hidden_Language    *Language              `protobuf:"bytes,5,opt,name=language"`
+	xxx_hidden_Results     *[]*Result             `protobuf:"bytes,6,rep,name=results"`
+	xxx_hidden_Source      *string                `protobuf:"bytes,7,opt,name=source"`
+	xxx_hidden_InputAnswer *[]*InputAnswer        `protobuf:"bytes,8,rep,name=inputAnswer"`
+	xxx_hidden_Log         *string                `protobuf:"bytes,9,opt,name=log"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *JudgeClientResponse) Reset() {
+	*x = JudgeClientResponse{}
+	mi := &file_demo_backend_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JudgeClientResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*JudgeClientResponse) ProtoMessage() {}
 
 func (x *JudgeClientResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[10]
+	mi := &file_demo_backend_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1683,19 +2726,38 @@ func (x *JudgeClientResponse) GetSource() string {
 	return ""
 }
 
+func (x *JudgeClientResponse) GetInputAnswer() []*InputAnswer {
+	if x != nil {
+		if x.xxx_hidden_InputAnswer != nil {
+			return *x.xxx_hidden_InputAnswer
+		}
+	}
+	return nil
+}
+
+func (x *JudgeClientResponse) GetLog() string {
+	if x != nil {
+		if x.xxx_hidden_Log != nil {
+			return *x.xxx_hidden_Log
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *JudgeClientResponse) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
 }
 
 func (x *JudgeClientResponse) SetType(v string) {
 	x.xxx_hidden_Type = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
 }
 
 func (x *JudgeClientResponse) SetStatus(v string) {
 	x.xxx_hidden_Status = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
 }
 
 func (x *JudgeClientResponse) SetDate(v *timestamppb.Timestamp) {
@@ -1712,7 +2774,16 @@ func (x *JudgeClientResponse) SetResults(v []*Result) {
 
 func (x *JudgeClientResponse) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *JudgeClientResponse) SetInputAnswer(v []*InputAnswer) {
+	x.xxx_hidden_InputAnswer = &v
+}
+
+func (x *JudgeClientResponse) SetLog(v string) {
+	x.xxx_hidden_Log = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
 }
 
 func (x *JudgeClientResponse) HasId() bool {
@@ -1757,6 +2828,13 @@ func (x *JudgeClientResponse) HasSource() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
+func (x *JudgeClientResponse) HasLog() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
 func (x *JudgeClientResponse) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = nil
@@ -1785,41 +2863,53 @@ func (x *JudgeClientResponse) ClearSource() {
 	x.xxx_hidden_Source = nil
 }
 
+func (x *JudgeClientResponse) ClearLog() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Log = nil
+}
+
 type JudgeClientResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id       *string
-	Type     *string
-	Status   *string
-	Date     *timestamppb.Timestamp
-	Language *Language
-	Results  []*Result
-	Source   *string
-}
-
+	Id          *string
+	Type        *string
+	Status      *string
+	Date        *timestamppb.Timestamp
+	Language    *Language
+	Results     []*Result
+	Source      *string
+	InputAnswer []*InputAnswer
+	Log         *string
+}
+
 func (b0 JudgeClientResponse_builder) Build() *JudgeClientResponse {
 	m0 := &JudgeClientResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
 		x.xxx_hidden_Id = b.Id
 	}
 	if b.Type != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
 		x.xxx_hidden_Type = b.Type
 	}
 	if b.Status != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
 		x.xxx_hidden_Status = b.Status
 	}
 	x.xxx_hidden_Date = b.Date
 	x.xxx_hidden_Language = b.Language
 	x.xxx_hidden_Results = &b.Results
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
 		x.xxx_hidden_Source = b.Source
 	}
+	x.xxx_hidden_InputAnswer = &b.InputAnswer
+	if b.Log != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_Log = b.Log
+	}
 	return m0
 }
 
@@ -1834,7 +2924,7 @@ type Input struct {
 
 func (x *Input) Reset() {
 	*x = Input{}
-	mi := &file_demo_backend_proto_msgTypes[11]
+	mi := &file_demo_backend_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1846,7 +2936,7 @@ func (x *Input) String() string {
 func (*Input) ProtoMessage() {}
 
 func (x *Input) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[11]
+	mi := &file_demo_backend_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +3005,7 @@ type Resize struct {
 
 func (x *Resize) Reset() {
 	*x = Resize{}
-	mi := &file_demo_backend_proto_msgTypes[12]
+	mi := &file_demo_backend_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1927,7 +3017,7 @@ func (x *Resize) String() string {
 func (*Resize) ProtoMessage() {}
 
 func (x *Resize) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[12]
+	mi := &file_demo_backend_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2075,7 +3165,7 @@ type ShellInput struct {
 
 func (x *ShellInput) Reset() {
 	*x = ShellInput{}
-	mi := &file_demo_backend_proto_msgTypes[13]
+	mi := &file_demo_backend_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2087,7 +3177,7 @@ func (x *ShellInput) String() string {
 func (*ShellInput) ProtoMessage() {}
 
 func (x *ShellInput) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[13]
+	mi := &file_demo_backend_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2116,6 +3206,33 @@ func (x *ShellInput) GetResize() *Resize {
 	return nil
 }
 
+func (x *ShellInput) GetAttach() *ShellAttach {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Request.(*shellInput_Attach); ok {
+			return x.Attach
+		}
+	}
+	return nil
+}
+
+func (x *ShellInput) GetShare() *ShellShare {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Request.(*shellInput_Share); ok {
+			return x.Share
+		}
+	}
+	return nil
+}
+
+func (x *ShellInput) GetTransfer() *ShellTransfer {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Request.(*shellInput_Transfer); ok {
+			return x.Transfer
+		}
+	}
+	return nil
+}
+
 func (x *ShellInput) SetInput(v *Input) {
 	if v == nil {
 		x.xxx_hidden_Request = nil
@@ -2132,6 +3249,30 @@ func (x *ShellInput) SetResize(v *Resize) {
 	x.xxx_hidden_Request = &shellInput_Resize{v}
 }
 
+func (x *ShellInput) SetAttach(v *ShellAttach) {
+	if v == nil {
+		x.xxx_hidden_Request = nil
+		return
+	}
+	x.xxx_hidden_Request = &shellInput_Attach{v}
+}
+
+func (x *ShellInput) SetShare(v *ShellShare) {
+	if v == nil {
+		x.xxx_hidden_Request = nil
+		return
+	}
+	x.xxx_hidden_Request = &shellInput_Share{v}
+}
+
+func (x *ShellInput) SetTransfer(v *ShellTransfer) {
+	if v == nil {
+		x.xxx_hidden_Request = nil
+		return
+	}
+	x.xxx_hidden_Request = &shellInput_Transfer{v}
+}
+
 func (x *ShellInput) HasRequest() bool {
 	if x == nil {
 		return false
@@ -2155,6 +3296,30 @@ func (x *ShellInput) HasResize() bool {
 	return ok
 }
 
+func (x *ShellInput) HasAttach() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Request.(*shellInput_Attach)
+	return ok
+}
+
+func (x *ShellInput) HasShare() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Request.(*shellInput_Share)
+	return ok
+}
+
+func (x *ShellInput) HasTransfer() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Request.(*shellInput_Transfer)
+	return ok
+}
+
 func (x *ShellInput) ClearRequest() {
 	x.xxx_hidden_Request = nil
 }
@@ -2171,9 +3336,30 @@ func (x *ShellInput) ClearResize() {
 	}
 }
 
+func (x *ShellInput) ClearAttach() {
+	if _, ok := x.xxx_hidden_Request.(*shellInput_Attach); ok {
+		x.xxx_hidden_Request = nil
+	}
+}
+
+func (x *ShellInput) ClearShare() {
+	if _, ok := x.xxx_hidden_Request.(*shellInput_Share); ok {
+		x.xxx_hidden_Request = nil
+	}
+}
+
+func (x *ShellInput) ClearTransfer() {
+	if _, ok := x.xxx_hidden_Request.(*shellInput_Transfer); ok {
+		x.xxx_hidden_Request = nil
+	}
+}
+
 const ShellInput_Request_not_set_case case_ShellInput_Request = 0
 const ShellInput_Input_case case_ShellInput_Request = 1
 const ShellInput_Resize_case case_ShellInput_Request = 2
+const ShellInput_Attach_case case_ShellInput_Request = 3
+const ShellInput_Share_case case_ShellInput_Request = 4
+const ShellInput_Transfer_case case_ShellInput_Request = 5
 
 func (x *ShellInput) WhichRequest() case_ShellInput_Request {
 	if x == nil {
@@ -2184,6 +3370,12 @@ func (x *ShellInput) WhichRequest() case_ShellInput_Request {
 		return ShellInput_Input_case
 	case *shellInput_Resize:
 		return ShellInput_Resize_case
+	case *shellInput_Attach:
+		return ShellInput_Attach_case
+	case *shellInput_Share:
+		return ShellInput_Share_case
+	case *shellInput_Transfer:
+		return ShellInput_Transfer_case
 	default:
 		return ShellInput_Request_not_set_case
 	}
@@ -2193,8 +3385,11 @@ type ShellInput_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// Fields of oneof xxx_hidden_Request:
-	Input  *Input
-	Resize *Resize
+	Input    *Input
+	Resize   *Resize
+	Attach   *ShellAttach
+	Share    *ShellShare
+	Transfer *ShellTransfer
 	// -- end of xxx_hidden_Request
 }
 
@@ -2208,13 +3403,22 @@ func (b0 ShellInput_builder) Build() *ShellInput {
 	if b.Resize != nil {
 		x.xxx_hidden_Request = &shellInput_Resize{b.Resize}
 	}
+	if b.Attach != nil {
+		x.xxx_hidden_Request = &shellInput_Attach{b.Attach}
+	}
+	if b.Share != nil {
+		x.xxx_hidden_Request = &shellInput_Share{b.Share}
+	}
+	if b.Transfer != nil {
+		x.xxx_hidden_Request = &shellInput_Transfer{b.Transfer}
+	}
 	return m0
 }
 
 type case_ShellInput_Request protoreflect.FieldNumber
 
 func (x case_ShellInput_Request) String() string {
-	md := file_demo_backend_proto_msgTypes[13].Descriptor()
+	md := file_demo_backend_proto_msgTypes[17].Descriptor()
 	if x == 0 {
 		return "not set"
 	}
@@ -2233,34 +3437,56 @@ type shellInput_Resize struct {
 	Resize *Resize `protobuf:"bytes,2,opt,name=resize,oneof"`
 }
 
+type shellInput_Attach struct {
+	Attach *ShellAttach `protobuf:"bytes,3,opt,name=attach,oneof"` // the first message
+}
+
+type shellInput_Share struct {
+	Share *ShellShare `protobuf:"bytes,4,opt,name=share,oneof"` // by the owner
+}
+
+type shellInput_Transfer struct {
+	Transfer *ShellTransfer `protobuf:"bytes,5,opt,name=transfer,oneof"`
+}
+
 func (*shellInput_Input) isShellInput_Request() {}
 
 func (*shellInput_Resize) isShellInput_Request() {}
 
-type ShellOutput struct {
+func (*shellInput_Attach) isShellInput_Request() {}
+
+func (*shellInput_Share) isShellInput_Request() {}
+
+func (*shellInput_Transfer) isShellInput_Request() {}
+
+type ShellAttach struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Content     []byte                 `protobuf:"bytes,2,opt,name=content"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=sessionId"`
+	xxx_hidden_Write       bool                   `protobuf:"varint,2,opt,name=write"`
+	xxx_hidden_Token       *string                `protobuf:"bytes,3,opt,name=token"`
+	xxx_hidden_Offset      uint64                 `protobuf:"varint,4,opt,name=offset"`
+	xxx_hidden_Profile     *string                `protobuf:"bytes,5,opt,name=profile"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *ShellOutput) Reset() {
-	*x = ShellOutput{}
-	mi := &file_demo_backend_proto_msgTypes[14]
+func (x *ShellAttach) Reset() {
+	*x = ShellAttach{}
+	mi := &file_demo_backend_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShellOutput) String() string {
+func (x *ShellAttach) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShellOutput) ProtoMessage() {}
+func (*ShellAttach) ProtoMessage() {}
 
-func (x *ShellOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_demo_backend_proto_msgTypes[14]
+func (x *ShellAttach) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2271,248 +3497,5761 @@ func (x *ShellOutput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ShellOutput) GetContent() []byte {
+func (x *ShellAttach) GetSessionId() string {
 	if x != nil {
-		return x.xxx_hidden_Content
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *ShellOutput) SetContent(v []byte) {
-	if v == nil {
-		v = []byte{}
+func (x *ShellAttach) GetWrite() bool {
+	if x != nil {
+		return x.xxx_hidden_Write
 	}
-	x.xxx_hidden_Content = v
+	return false
+}
+
+func (x *ShellAttach) GetToken() string {
+	if x != nil {
+		if x.xxx_hidden_Token != nil {
+			return *x.xxx_hidden_Token
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellAttach) GetOffset() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *ShellAttach) GetProfile() string {
+	if x != nil {
+		if x.xxx_hidden_Profile != nil {
+			return *x.xxx_hidden_Profile
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellAttach) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *ShellAttach) SetWrite(v bool) {
+	x.xxx_hidden_Write = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *ShellAttach) SetToken(v string) {
+	x.xxx_hidden_Token = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *ShellAttach) SetOffset(v uint64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *ShellAttach) SetProfile(v string) {
+	x.xxx_hidden_Profile = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *ShellAttach) HasSessionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellAttach) HasWrite() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellAttach) HasToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellAttach) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ShellAttach) HasProfile() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ShellAttach) ClearSessionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SessionId = nil
+}
+
+func (x *ShellAttach) ClearWrite() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Write = false
+}
+
+func (x *ShellAttach) ClearToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Token = nil
+}
+
+func (x *ShellAttach) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *ShellAttach) ClearProfile() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Profile = nil
+}
+
+type ShellAttach_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	SessionId *string
+	Write     *bool
+	Token     *string
+	Offset    *uint64
+	Profile   *string
+}
+
+func (b0 ShellAttach_builder) Build() *ShellAttach {
+	m0 := &ShellAttach{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	if b.Write != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Write = *b.Write
+	}
+	if b.Token != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Token = b.Token
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Profile != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Profile = b.Profile
+	}
+	return m0
+}
+
+type ShellShare struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Write       bool                   `protobuf:"varint,1,opt,name=write"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellShare) Reset() {
+	*x = ShellShare{}
+	mi := &file_demo_backend_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellShare) ProtoMessage() {}
+
+func (x *ShellShare) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellShare) GetWrite() bool {
+	if x != nil {
+		return x.xxx_hidden_Write
+	}
+	return false
+}
+
+func (x *ShellShare) SetWrite(v bool) {
+	x.xxx_hidden_Write = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
 }
 
-func (x *ShellOutput) HasContent() bool {
+func (x *ShellShare) HasWrite() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *ShellOutput) ClearContent() {
+func (x *ShellShare) ClearWrite() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Content = nil
+	x.xxx_hidden_Write = false
 }
 
-type ShellOutput_builder struct {
+type ShellShare_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Content []byte
+	Write *bool
 }
 
-func (b0 ShellOutput_builder) Build() *ShellOutput {
-	m0 := &ShellOutput{}
+func (b0 ShellShare_builder) Build() *ShellShare {
+	m0 := &ShellShare{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Content != nil {
+	if b.Write != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Content = b.Content
+		x.xxx_hidden_Write = *b.Write
 	}
 	return m0
 }
 
+// ShellTransfer uploads or downloads a file in the working directory of the
+// running shell, and is replied to the client with the result
+type ShellTransfer struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Download    bool                   `protobuf:"varint,2,opt,name=download"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,3,opt,name=content"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,4,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellTransfer) Reset() {
+	*x = ShellTransfer{}
+	mi := &file_demo_backend_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellTransfer) ProtoMessage() {}
+
+func (x *ShellTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellTransfer) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellTransfer) GetDownload() bool {
+	if x != nil {
+		return x.xxx_hidden_Download
+	}
+	return false
+}
+
+func (x *ShellTransfer) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *ShellTransfer) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellTransfer) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ShellTransfer) SetDownload(v bool) {
+	x.xxx_hidden_Download = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *ShellTransfer) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ShellTransfer) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *ShellTransfer) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellTransfer) HasDownload() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellTransfer) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellTransfer) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ShellTransfer) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ShellTransfer) ClearDownload() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Download = false
+}
+
+func (x *ShellTransfer) ClearContent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Content = nil
+}
+
+func (x *ShellTransfer) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Error = nil
+}
+
+type ShellTransfer_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name     *string
+	Download *bool
+	Content  []byte
+	Error    *string
+}
+
+func (b0 ShellTransfer_builder) Build() *ShellTransfer {
+	m0 := &ShellTransfer{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Download != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Download = *b.Download
+	}
+	if b.Content != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Content = b.Content
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+type ShellOutput struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,2,opt,name=content"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,3,opt,name=sessionId"`
+	xxx_hidden_Write       bool                   `protobuf:"varint,4,opt,name=write"`
+	xxx_hidden_Token       *string                `protobuf:"bytes,5,opt,name=token"`
+	xxx_hidden_Offset      uint64                 `protobuf:"varint,6,opt,name=offset"`
+	xxx_hidden_RecordId    *string                `protobuf:"bytes,7,opt,name=recordId"`
+	xxx_hidden_Exit        *ShellExit             `protobuf:"bytes,8,opt,name=exit"`
+	xxx_hidden_Notice      *string                `protobuf:"bytes,9,opt,name=notice"`
+	xxx_hidden_Transfer    *ShellTransfer         `protobuf:"bytes,10,opt,name=transfer"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellOutput) Reset() {
+	*x = ShellOutput{}
+	mi := &file_demo_backend_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellOutput) ProtoMessage() {}
+
+func (x *ShellOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellOutput) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *ShellOutput) GetSessionId() string {
+	if x != nil {
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellOutput) GetWrite() bool {
+	if x != nil {
+		return x.xxx_hidden_Write
+	}
+	return false
+}
+
+func (x *ShellOutput) GetToken() string {
+	if x != nil {
+		if x.xxx_hidden_Token != nil {
+			return *x.xxx_hidden_Token
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellOutput) GetOffset() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *ShellOutput) GetRecordId() string {
+	if x != nil {
+		if x.xxx_hidden_RecordId != nil {
+			return *x.xxx_hidden_RecordId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellOutput) GetExit() *ShellExit {
+	if x != nil {
+		return x.xxx_hidden_Exit
+	}
+	return nil
+}
+
+func (x *ShellOutput) GetNotice() string {
+	if x != nil {
+		if x.xxx_hidden_Notice != nil {
+			return *x.xxx_hidden_Notice
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellOutput) GetTransfer() *ShellTransfer {
+	if x != nil {
+		return x.xxx_hidden_Transfer
+	}
+	return nil
+}
+
+func (x *ShellOutput) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *ShellOutput) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *ShellOutput) SetWrite(v bool) {
+	x.xxx_hidden_Write = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *ShellOutput) SetToken(v string) {
+	x.xxx_hidden_Token = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *ShellOutput) SetOffset(v uint64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *ShellOutput) SetRecordId(v string) {
+	x.xxx_hidden_RecordId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *ShellOutput) SetExit(v *ShellExit) {
+	x.xxx_hidden_Exit = v
+}
+
+func (x *ShellOutput) SetNotice(v string) {
+	x.xxx_hidden_Notice = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *ShellOutput) SetTransfer(v *ShellTransfer) {
+	x.xxx_hidden_Transfer = v
+}
+
+func (x *ShellOutput) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellOutput) HasSessionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellOutput) HasWrite() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellOutput) HasToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ShellOutput) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ShellOutput) HasRecordId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ShellOutput) HasExit() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Exit != nil
+}
+
+func (x *ShellOutput) HasNotice() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *ShellOutput) HasTransfer() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Transfer != nil
+}
+
+func (x *ShellOutput) ClearContent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Content = nil
+}
+
+func (x *ShellOutput) ClearSessionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_SessionId = nil
+}
+
+func (x *ShellOutput) ClearWrite() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Write = false
+}
+
+func (x *ShellOutput) ClearToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Token = nil
+}
+
+func (x *ShellOutput) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *ShellOutput) ClearRecordId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_RecordId = nil
+}
+
+func (x *ShellOutput) ClearExit() {
+	x.xxx_hidden_Exit = nil
+}
+
+func (x *ShellOutput) ClearNotice() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Notice = nil
+}
+
+func (x *ShellOutput) ClearTransfer() {
+	x.xxx_hidden_Transfer = nil
+}
+
+type ShellOutput_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Content   []byte
+	SessionId *string
+	Write     *bool
+	Token     *string
+	Offset    *uint64
+	RecordId  *string
+	Exit      *ShellExit
+	Notice    *string
+	Transfer  *ShellTransfer
+}
+
+func (b0 ShellOutput_builder) Build() *ShellOutput {
+	m0 := &ShellOutput{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Content != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_Content = b.Content
+	}
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	if b.Write != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Write = *b.Write
+	}
+	if b.Token != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_Token = b.Token
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.RecordId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_RecordId = b.RecordId
+	}
+	x.xxx_hidden_Exit = b.Exit
+	if b.Notice != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_Notice = b.Notice
+	}
+	x.xxx_hidden_Transfer = b.Transfer
+	return m0
+}
+
+// ShellExit is the result of the shell, or the reason it was closed
+type ShellExit struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Status      *string                `protobuf:"bytes,1,opt,name=status"`
+	xxx_hidden_ExitStatus  int32                  `protobuf:"varint,2,opt,name=exitStatus"`
+	xxx_hidden_Time        uint64                 `protobuf:"varint,3,opt,name=time"`
+	xxx_hidden_Memory      uint64                 `protobuf:"varint,4,opt,name=memory"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellExit) Reset() {
+	*x = ShellExit{}
+	mi := &file_demo_backend_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellExit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellExit) ProtoMessage() {}
+
+func (x *ShellExit) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellExit) GetStatus() string {
+	if x != nil {
+		if x.xxx_hidden_Status != nil {
+			return *x.xxx_hidden_Status
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellExit) GetExitStatus() int32 {
+	if x != nil {
+		return x.xxx_hidden_ExitStatus
+	}
+	return 0
+}
+
+func (x *ShellExit) GetTime() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return 0
+}
+
+func (x *ShellExit) GetMemory() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Memory
+	}
+	return 0
+}
+
+func (x *ShellExit) SetStatus(v string) {
+	x.xxx_hidden_Status = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ShellExit) SetExitStatus(v int32) {
+	x.xxx_hidden_ExitStatus = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *ShellExit) SetTime(v uint64) {
+	x.xxx_hidden_Time = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ShellExit) SetMemory(v uint64) {
+	x.xxx_hidden_Memory = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *ShellExit) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellExit) HasExitStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellExit) HasTime() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellExit) HasMemory() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ShellExit) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Status = nil
+}
+
+func (x *ShellExit) ClearExitStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ExitStatus = 0
+}
+
+func (x *ShellExit) ClearTime() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Time = 0
+}
+
+func (x *ShellExit) ClearMemory() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Memory = 0
+}
+
+type ShellExit_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Status     *string
+	ExitStatus *int32
+	Time       *uint64
+	Memory     *uint64
+}
+
+func (b0 ShellExit_builder) Build() *ShellExit {
+	m0 := &ShellExit{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Status = b.Status
+	}
+	if b.ExitStatus != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_ExitStatus = *b.ExitStatus
+	}
+	if b.Time != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Time = *b.Time
+	}
+	if b.Memory != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Memory = *b.Memory
+	}
+	return m0
+}
+
+type Checker struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Language    *Language              `protobuf:"bytes,1,opt,name=language"`
+	xxx_hidden_Source      *string                `protobuf:"bytes,2,opt,name=source"`
+	xxx_hidden_Files       *[]*SourceFile         `protobuf:"bytes,3,rep,name=files"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Checker) Reset() {
+	*x = Checker{}
+	mi := &file_demo_backend_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Checker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checker) ProtoMessage() {}
+
+func (x *Checker) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Checker) GetLanguage() *Language {
+	if x != nil {
+		return x.xxx_hidden_Language
+	}
+	return nil
+}
+
+func (x *Checker) GetSource() string {
+	if x != nil {
+		if x.xxx_hidden_Source != nil {
+			return *x.xxx_hidden_Source
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Checker) GetFiles() []*SourceFile {
+	if x != nil {
+		if x.xxx_hidden_Files != nil {
+			return *x.xxx_hidden_Files
+		}
+	}
+	return nil
+}
+
+func (x *Checker) SetLanguage(v *Language) {
+	x.xxx_hidden_Language = v
+}
+
+func (x *Checker) SetSource(v string) {
+	x.xxx_hidden_Source = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *Checker) SetFiles(v []*SourceFile) {
+	x.xxx_hidden_Files = &v
+}
+
+func (x *Checker) HasLanguage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Language != nil
+}
+
+func (x *Checker) HasSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Checker) ClearLanguage() {
+	x.xxx_hidden_Language = nil
+}
+
+func (x *Checker) ClearSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Source = nil
+}
+
+type Checker_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Language *Language
+	Source   *string
+	Files    []*SourceFile
+}
+
+func (b0 Checker_builder) Build() *Checker {
+	m0 := &Checker{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Language = b.Language
+	if b.Source != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Source = b.Source
+	}
+	x.xxx_hidden_Files = &b.Files
+	return m0
+}
+
+type Problem struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Title       *string                `protobuf:"bytes,2,opt,name=title"`
+	xxx_hidden_Statement   *string                `protobuf:"bytes,3,opt,name=statement"`
+	xxx_hidden_TimeLimit   uint64                 `protobuf:"varint,4,opt,name=timeLimit"`
+	xxx_hidden_MemoryLimit uint64                 `protobuf:"varint,5,opt,name=memoryLimit"`
+	xxx_hidden_Checker     *Checker               `protobuf:"bytes,6,opt,name=checker"`
+	xxx_hidden_TestCases   *[]*InputAnswer        `protobuf:"bytes,7,rep,name=testCases"`
+	xxx_hidden_Date        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=date"`
+	xxx_hidden_BuildStatus *string                `protobuf:"bytes,9,opt,name=buildStatus"`
+	xxx_hidden_BuildLog    *string                `protobuf:"bytes,10,opt,name=buildLog"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Problem) Reset() {
+	*x = Problem{}
+	mi := &file_demo_backend_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Problem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Problem) ProtoMessage() {}
+
+func (x *Problem) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Problem) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Problem) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Problem) GetStatement() string {
+	if x != nil {
+		if x.xxx_hidden_Statement != nil {
+			return *x.xxx_hidden_Statement
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Problem) GetTimeLimit() uint64 {
+	if x != nil {
+		return x.xxx_hidden_TimeLimit
+	}
+	return 0
+}
+
+func (x *Problem) GetMemoryLimit() uint64 {
+	if x != nil {
+		return x.xxx_hidden_MemoryLimit
+	}
+	return 0
+}
+
+func (x *Problem) GetChecker() *Checker {
+	if x != nil {
+		return x.xxx_hidden_Checker
+	}
+	return nil
+}
+
+func (x *Problem) GetTestCases() []*InputAnswer {
+	if x != nil {
+		if x.xxx_hidden_TestCases != nil {
+			return *x.xxx_hidden_TestCases
+		}
+	}
+	return nil
+}
+
+func (x *Problem) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Date
+	}
+	return nil
+}
+
+func (x *Problem) GetBuildStatus() string {
+	if x != nil {
+		if x.xxx_hidden_BuildStatus != nil {
+			return *x.xxx_hidden_BuildStatus
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Problem) GetBuildLog() string {
+	if x != nil {
+		if x.xxx_hidden_BuildLog != nil {
+			return *x.xxx_hidden_BuildLog
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Problem) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *Problem) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *Problem) SetStatement(v string) {
+	x.xxx_hidden_Statement = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *Problem) SetTimeLimit(v uint64) {
+	x.xxx_hidden_TimeLimit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *Problem) SetMemoryLimit(v uint64) {
+	x.xxx_hidden_MemoryLimit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *Problem) SetChecker(v *Checker) {
+	x.xxx_hidden_Checker = v
+}
+
+func (x *Problem) SetTestCases(v []*InputAnswer) {
+	x.xxx_hidden_TestCases = &v
+}
+
+func (x *Problem) SetDate(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Date = v
+}
+
+func (x *Problem) SetBuildStatus(v string) {
+	x.xxx_hidden_BuildStatus = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *Problem) SetBuildLog(v string) {
+	x.xxx_hidden_BuildLog = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+}
+
+func (x *Problem) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Problem) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Problem) HasStatement() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Problem) HasTimeLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *Problem) HasMemoryLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *Problem) HasChecker() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Checker != nil
+}
+
+func (x *Problem) HasDate() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Date != nil
+}
+
+func (x *Problem) HasBuildStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *Problem) HasBuildLog() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *Problem) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *Problem) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *Problem) ClearStatement() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Statement = nil
+}
+
+func (x *Problem) ClearTimeLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_TimeLimit = 0
+}
+
+func (x *Problem) ClearMemoryLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_MemoryLimit = 0
+}
+
+func (x *Problem) ClearChecker() {
+	x.xxx_hidden_Checker = nil
+}
+
+func (x *Problem) ClearDate() {
+	x.xxx_hidden_Date = nil
+}
+
+func (x *Problem) ClearBuildStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_BuildStatus = nil
+}
+
+func (x *Problem) ClearBuildLog() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_BuildLog = nil
+}
+
+type Problem_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id          *string
+	Title       *string
+	Statement   *string
+	TimeLimit   *uint64
+	MemoryLimit *uint64
+	Checker     *Checker
+	TestCases   []*InputAnswer
+	Date        *timestamppb.Timestamp
+	BuildStatus *string
+	BuildLog    *string
+}
+
+func (b0 Problem_builder) Build() *Problem {
+	m0 := &Problem{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.Statement != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_Statement = b.Statement
+	}
+	if b.TimeLimit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_TimeLimit = *b.TimeLimit
+	}
+	if b.MemoryLimit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_MemoryLimit = *b.MemoryLimit
+	}
+	x.xxx_hidden_Checker = b.Checker
+	x.xxx_hidden_TestCases = &b.TestCases
+	x.xxx_hidden_Date = b.Date
+	if b.BuildStatus != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_BuildStatus = b.BuildStatus
+	}
+	if b.BuildLog != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_BuildLog = b.BuildLog
+	}
+	return m0
+}
+
+type GetProblemRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_TestData    bool                   `protobuf:"varint,2,opt,name=testData"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetProblemRequest) Reset() {
+	*x = GetProblemRequest{}
+	mi := &file_demo_backend_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProblemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProblemRequest) ProtoMessage() {}
+
+func (x *GetProblemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetProblemRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetProblemRequest) GetTestData() bool {
+	if x != nil {
+		return x.xxx_hidden_TestData
+	}
+	return false
+}
+
+func (x *GetProblemRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *GetProblemRequest) SetTestData(v bool) {
+	x.xxx_hidden_TestData = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *GetProblemRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetProblemRequest) HasTestData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetProblemRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *GetProblemRequest) ClearTestData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_TestData = false
+}
+
+type GetProblemRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id       *string
+	TestData *bool
+}
+
+func (b0 GetProblemRequest_builder) Build() *GetProblemRequest {
+	m0 := &GetProblemRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.TestData != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_TestData = *b.TestData
+	}
+	return m0
+}
+
+type ListProblemsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListProblemsRequest) Reset() {
+	*x = ListProblemsRequest{}
+	mi := &file_demo_backend_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProblemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProblemsRequest) ProtoMessage() {}
+
+func (x *ListProblemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListProblemsRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ListProblemsRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ListProblemsRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListProblemsRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type ListProblemsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 ListProblemsRequest_builder) Build() *ListProblemsRequest {
+	m0 := &ListProblemsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type ListProblemsResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Problems *[]*Problem            `protobuf:"bytes,1,rep,name=problems"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListProblemsResponse) Reset() {
+	*x = ListProblemsResponse{}
+	mi := &file_demo_backend_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProblemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProblemsResponse) ProtoMessage() {}
+
+func (x *ListProblemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListProblemsResponse) GetProblems() []*Problem {
+	if x != nil {
+		if x.xxx_hidden_Problems != nil {
+			return *x.xxx_hidden_Problems
+		}
+	}
+	return nil
+}
+
+func (x *ListProblemsResponse) SetProblems(v []*Problem) {
+	x.xxx_hidden_Problems = &v
+}
+
+type ListProblemsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Problems []*Problem
+}
+
+func (b0 ListProblemsResponse_builder) Build() *ListProblemsResponse {
+	m0 := &ListProblemsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Problems = &b.Problems
+	return m0
+}
+
+type ImportProblemRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Package     []byte                 `protobuf:"bytes,1,opt,name=package"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,2,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ImportProblemRequest) Reset() {
+	*x = ImportProblemRequest{}
+	mi := &file_demo_backend_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProblemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProblemRequest) ProtoMessage() {}
+
+func (x *ImportProblemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ImportProblemRequest) GetPackage() []byte {
+	if x != nil {
+		return x.xxx_hidden_Package
+	}
+	return nil
+}
+
+func (x *ImportProblemRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ImportProblemRequest) SetPackage(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Package = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ImportProblemRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ImportProblemRequest) HasPackage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ImportProblemRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ImportProblemRequest) ClearPackage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Package = nil
+}
+
+func (x *ImportProblemRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = nil
+}
+
+type ImportProblemRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Package []byte
+	Id      *string
+}
+
+func (b0 ImportProblemRequest_builder) Build() *ImportProblemRequest {
+	m0 := &ImportProblemRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Package != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Package = b.Package
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type Program struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Language    *Language              `protobuf:"bytes,2,opt,name=language"`
+	xxx_hidden_Source      *string                `protobuf:"bytes,3,opt,name=source"`
+	xxx_hidden_Files       *[]*SourceFile         `protobuf:"bytes,4,rep,name=files"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Program) Reset() {
+	*x = Program{}
+	mi := &file_demo_backend_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Program) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Program) GetLanguage() *Language {
+	if x != nil {
+		return x.xxx_hidden_Language
+	}
+	return nil
+}
+
+func (x *Program) GetSource() string {
+	if x != nil {
+		if x.xxx_hidden_Source != nil {
+			return *x.xxx_hidden_Source
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Program) GetFiles() []*SourceFile {
+	if x != nil {
+		if x.xxx_hidden_Files != nil {
+			return *x.xxx_hidden_Files
+		}
+	}
+	return nil
+}
+
+func (x *Program) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *Program) SetLanguage(v *Language) {
+	x.xxx_hidden_Language = v
+}
+
+func (x *Program) SetSource(v string) {
+	x.xxx_hidden_Source = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *Program) SetFiles(v []*SourceFile) {
+	x.xxx_hidden_Files = &v
+}
+
+func (x *Program) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Program) HasLanguage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Language != nil
+}
+
+func (x *Program) HasSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Program) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *Program) ClearLanguage() {
+	x.xxx_hidden_Language = nil
+}
+
+func (x *Program) ClearSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Source = nil
+}
+
+type Program_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name     *string
+	Language *Language
+	Source   *string
+	Files    []*SourceFile
+}
+
+func (b0 Program_builder) Build() *Program {
+	m0 := &Program{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Name = b.Name
+	}
+	x.xxx_hidden_Language = b.Language
+	if b.Source != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Source = b.Source
+	}
+	x.xxx_hidden_Files = &b.Files
+	return m0
+}
+
+type BuildProblemRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ProblemId   *string                `protobuf:"bytes,1,opt,name=problemId"`
+	xxx_hidden_Generators  *[]*Program            `protobuf:"bytes,2,rep,name=generators"`
+	xxx_hidden_Validator   *Program               `protobuf:"bytes,3,opt,name=validator"`
+	xxx_hidden_Solution    *Program               `protobuf:"bytes,4,opt,name=solution"`
+	xxx_hidden_Script      *string                `protobuf:"bytes,5,opt,name=script"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BuildProblemRequest) Reset() {
+	*x = BuildProblemRequest{}
+	mi := &file_demo_backend_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildProblemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildProblemRequest) ProtoMessage() {}
+
+func (x *BuildProblemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildProblemRequest) GetProblemId() string {
+	if x != nil {
+		if x.xxx_hidden_ProblemId != nil {
+			return *x.xxx_hidden_ProblemId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildProblemRequest) GetGenerators() []*Program {
+	if x != nil {
+		if x.xxx_hidden_Generators != nil {
+			return *x.xxx_hidden_Generators
+		}
+	}
+	return nil
+}
+
+func (x *BuildProblemRequest) GetValidator() *Program {
+	if x != nil {
+		return x.xxx_hidden_Validator
+	}
+	return nil
+}
+
+func (x *BuildProblemRequest) GetSolution() *Program {
+	if x != nil {
+		return x.xxx_hidden_Solution
+	}
+	return nil
+}
+
+func (x *BuildProblemRequest) GetScript() string {
+	if x != nil {
+		if x.xxx_hidden_Script != nil {
+			return *x.xxx_hidden_Script
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildProblemRequest) SetProblemId(v string) {
+	x.xxx_hidden_ProblemId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *BuildProblemRequest) SetGenerators(v []*Program) {
+	x.xxx_hidden_Generators = &v
+}
+
+func (x *BuildProblemRequest) SetValidator(v *Program) {
+	x.xxx_hidden_Validator = v
+}
+
+func (x *BuildProblemRequest) SetSolution(v *Program) {
+	x.xxx_hidden_Solution = v
+}
+
+func (x *BuildProblemRequest) SetScript(v string) {
+	x.xxx_hidden_Script = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *BuildProblemRequest) HasProblemId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildProblemRequest) HasValidator() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Validator != nil
+}
+
+func (x *BuildProblemRequest) HasSolution() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Solution != nil
+}
+
+func (x *BuildProblemRequest) HasScript() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *BuildProblemRequest) ClearProblemId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ProblemId = nil
+}
+
+func (x *BuildProblemRequest) ClearValidator() {
+	x.xxx_hidden_Validator = nil
+}
+
+func (x *BuildProblemRequest) ClearSolution() {
+	x.xxx_hidden_Solution = nil
+}
+
+func (x *BuildProblemRequest) ClearScript() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Script = nil
+}
+
+type BuildProblemRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ProblemId  *string
+	Generators []*Program
+	Validator  *Program
+	Solution   *Program
+	Script     *string
+}
+
+func (b0 BuildProblemRequest_builder) Build() *BuildProblemRequest {
+	m0 := &BuildProblemRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ProblemId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_ProblemId = b.ProblemId
+	}
+	x.xxx_hidden_Generators = &b.Generators
+	x.xxx_hidden_Validator = b.Validator
+	x.xxx_hidden_Solution = b.Solution
+	if b.Script != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Script = b.Script
+	}
+	return m0
+}
+
+type BuildProblemResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BuildProblemResponse) Reset() {
+	*x = BuildProblemResponse{}
+	mi := &file_demo_backend_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildProblemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildProblemResponse) ProtoMessage() {}
+
+func (x *BuildProblemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildProblemResponse) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildProblemResponse) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *BuildProblemResponse) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildProblemResponse) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type BuildProblemResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 BuildProblemResponse_builder) Build() *BuildProblemResponse {
+	m0 := &BuildProblemResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type FetchBlobRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hash        *string                `protobuf:"bytes,1,opt,name=hash"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FetchBlobRequest) Reset() {
+	*x = FetchBlobRequest{}
+	mi := &file_demo_backend_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchBlobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchBlobRequest) ProtoMessage() {}
+
+func (x *FetchBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FetchBlobRequest) GetHash() string {
+	if x != nil {
+		if x.xxx_hidden_Hash != nil {
+			return *x.xxx_hidden_Hash
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FetchBlobRequest) SetHash(v string) {
+	x.xxx_hidden_Hash = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *FetchBlobRequest) HasHash() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FetchBlobRequest) ClearHash() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Hash = nil
+}
+
+type FetchBlobRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Hash *string
+}
+
+func (b0 FetchBlobRequest_builder) Build() *FetchBlobRequest {
+	m0 := &FetchBlobRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Hash != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Hash = b.Hash
+	}
+	return m0
+}
+
+type BlobChunk struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,1,opt,name=content"`
+	xxx_hidden_Size        uint64                 `protobuf:"varint,2,opt,name=size"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BlobChunk) Reset() {
+	*x = BlobChunk{}
+	mi := &file_demo_backend_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlobChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobChunk) ProtoMessage() {}
+
+func (x *BlobChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BlobChunk) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *BlobChunk) GetSize() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *BlobChunk) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *BlobChunk) SetSize(v uint64) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *BlobChunk) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BlobChunk) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BlobChunk) ClearContent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Content = nil
+}
+
+func (x *BlobChunk) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Size = 0
+}
+
+type BlobChunk_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Content []byte
+	Size    *uint64
+}
+
+func (b0 BlobChunk_builder) Build() *BlobChunk {
+	m0 := &BlobChunk{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Content != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Content = b.Content
+	}
+	if b.Size != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Size = *b.Size
+	}
+	return m0
+}
+
+// User is created by apigateway, the password is hashed before sent to demo server
+// and the hash is never returned
+type User struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id           *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Name         *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_PasswordHash []byte                 `protobuf:"bytes,3,opt,name=passwordHash"`
+	xxx_hidden_Roles        []string               `protobuf:"bytes,4,rep,name=roles"`
+	xxx_hidden_Date         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=date"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_demo_backend_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User) GetPasswordHash() []byte {
+	if x != nil {
+		return x.xxx_hidden_PasswordHash
+	}
+	return nil
+}
+
+func (x *User) GetRoles() []string {
+	if x != nil {
+		return x.xxx_hidden_Roles
+	}
+	return nil
+}
+
+func (x *User) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Date
+	}
+	return nil
+}
+
+func (x *User) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *User) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *User) SetPasswordHash(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_PasswordHash = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *User) SetRoles(v []string) {
+	x.xxx_hidden_Roles = v
+}
+
+func (x *User) SetDate(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Date = v
+}
+
+func (x *User) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *User) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *User) HasPasswordHash() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *User) HasDate() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Date != nil
+}
+
+func (x *User) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *User) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *User) ClearPasswordHash() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_PasswordHash = nil
+}
+
+func (x *User) ClearDate() {
+	x.xxx_hidden_Date = nil
+}
+
+type User_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id           *string
+	Name         *string
+	PasswordHash []byte
+	Roles        []string
+	Date         *timestamppb.Timestamp
+}
+
+func (b0 User_builder) Build() *User {
+	m0 := &User{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.PasswordHash != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_PasswordHash = b.PasswordHash
+	}
+	x.xxx_hidden_Roles = b.Roles
+	x.xxx_hidden_Date = b.Date
+	return m0
+}
+
+type GetUserRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_demo_backend_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetUserRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetUserRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *GetUserRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *GetUserRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetUserRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetUserRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *GetUserRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+type GetUserRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id   *string
+	Name *string
+}
+
+func (b0 GetUserRequest_builder) Build() *GetUserRequest {
+	m0 := &GetUserRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+// VerifyUserRequest checks the password of the user on login
+type VerifyUserRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Password    *string                `protobuf:"bytes,2,opt,name=password"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *VerifyUserRequest) Reset() {
+	*x = VerifyUserRequest{}
+	mi := &file_demo_backend_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyUserRequest) ProtoMessage() {}
+
+func (x *VerifyUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *VerifyUserRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *VerifyUserRequest) GetPassword() string {
+	if x != nil {
+		if x.xxx_hidden_Password != nil {
+			return *x.xxx_hidden_Password
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *VerifyUserRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *VerifyUserRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *VerifyUserRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *VerifyUserRequest) HasPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *VerifyUserRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *VerifyUserRequest) ClearPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Password = nil
+}
+
+type VerifyUserRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name     *string
+	Password *string
+}
+
+func (b0 VerifyUserRequest_builder) Build() *VerifyUserRequest {
+	m0 := &VerifyUserRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Password != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Password = b.Password
+	}
+	return m0
+}
+
+type ShellSession struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,2,opt,name=userId"`
+	xxx_hidden_UserName    *string                `protobuf:"bytes,3,opt,name=userName"`
+	xxx_hidden_RemoteAddr  *string                `protobuf:"bytes,4,opt,name=remoteAddr"`
+	xxx_hidden_Start       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start"`
+	xxx_hidden_End         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end"`
+	xxx_hidden_Width       uint32                 `protobuf:"varint,7,opt,name=width"`
+	xxx_hidden_Height      uint32                 `protobuf:"varint,8,opt,name=height"`
+	xxx_hidden_Status      *string                `protobuf:"bytes,9,opt,name=status"`
+	xxx_hidden_ExitStatus  int32                  `protobuf:"varint,10,opt,name=exitStatus"`
+	xxx_hidden_Time        uint64                 `protobuf:"varint,11,opt,name=time"`
+	xxx_hidden_Memory      uint64                 `protobuf:"varint,12,opt,name=memory"`
+	xxx_hidden_Events      uint64                 `protobuf:"varint,13,opt,name=events"`
+	xxx_hidden_Truncated   bool                   `protobuf:"varint,14,opt,name=truncated"`
+	xxx_hidden_Archive     *string                `protobuf:"bytes,15,opt,name=archive"`
+	xxx_hidden_Profile     *string                `protobuf:"bytes,16,opt,name=profile"`
+	xxx_hidden_Command     []string               `protobuf:"bytes,17,rep,name=command"`
+	xxx_hidden_Term        *string                `protobuf:"bytes,18,opt,name=term"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellSession) Reset() {
+	*x = ShellSession{}
+	mi := &file_demo_backend_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellSession) ProtoMessage() {}
+
+func (x *ShellSession) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellSession) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetUserName() string {
+	if x != nil {
+		if x.xxx_hidden_UserName != nil {
+			return *x.xxx_hidden_UserName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetRemoteAddr() string {
+	if x != nil {
+		if x.xxx_hidden_RemoteAddr != nil {
+			return *x.xxx_hidden_RemoteAddr
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Start
+	}
+	return nil
+}
+
+func (x *ShellSession) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_End
+	}
+	return nil
+}
+
+func (x *ShellSession) GetWidth() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Width
+	}
+	return 0
+}
+
+func (x *ShellSession) GetHeight() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Height
+	}
+	return 0
+}
+
+func (x *ShellSession) GetStatus() string {
+	if x != nil {
+		if x.xxx_hidden_Status != nil {
+			return *x.xxx_hidden_Status
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetExitStatus() int32 {
+	if x != nil {
+		return x.xxx_hidden_ExitStatus
+	}
+	return 0
+}
+
+func (x *ShellSession) GetTime() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return 0
+}
+
+func (x *ShellSession) GetMemory() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Memory
+	}
+	return 0
+}
+
+func (x *ShellSession) GetEvents() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Events
+	}
+	return 0
+}
+
+func (x *ShellSession) GetTruncated() bool {
+	if x != nil {
+		return x.xxx_hidden_Truncated
+	}
+	return false
+}
+
+func (x *ShellSession) GetArchive() string {
+	if x != nil {
+		if x.xxx_hidden_Archive != nil {
+			return *x.xxx_hidden_Archive
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetProfile() string {
+	if x != nil {
+		if x.xxx_hidden_Profile != nil {
+			return *x.xxx_hidden_Profile
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) GetCommand() []string {
+	if x != nil {
+		return x.xxx_hidden_Command
+	}
+	return nil
+}
+
+func (x *ShellSession) GetTerm() string {
+	if x != nil {
+		if x.xxx_hidden_Term != nil {
+			return *x.xxx_hidden_Term
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellSession) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 18)
+}
+
+func (x *ShellSession) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 18)
+}
+
+func (x *ShellSession) SetUserName(v string) {
+	x.xxx_hidden_UserName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 18)
+}
+
+func (x *ShellSession) SetRemoteAddr(v string) {
+	x.xxx_hidden_RemoteAddr = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 18)
+}
+
+func (x *ShellSession) SetStart(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Start = v
+}
+
+func (x *ShellSession) SetEnd(v *timestamppb.Timestamp) {
+	x.xxx_hidden_End = v
+}
+
+func (x *ShellSession) SetWidth(v uint32) {
+	x.xxx_hidden_Width = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 18)
+}
+
+func (x *ShellSession) SetHeight(v uint32) {
+	x.xxx_hidden_Height = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 18)
+}
+
+func (x *ShellSession) SetStatus(v string) {
+	x.xxx_hidden_Status = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 18)
+}
+
+func (x *ShellSession) SetExitStatus(v int32) {
+	x.xxx_hidden_ExitStatus = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 18)
+}
+
+func (x *ShellSession) SetTime(v uint64) {
+	x.xxx_hidden_Time = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 18)
+}
+
+func (x *ShellSession) SetMemory(v uint64) {
+	x.xxx_hidden_Memory = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 18)
+}
+
+func (x *ShellSession) SetEvents(v uint64) {
+	x.xxx_hidden_Events = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 18)
+}
+
+func (x *ShellSession) SetTruncated(v bool) {
+	x.xxx_hidden_Truncated = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 18)
+}
+
+func (x *ShellSession) SetArchive(v string) {
+	x.xxx_hidden_Archive = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 18)
+}
+
+func (x *ShellSession) SetProfile(v string) {
+	x.xxx_hidden_Profile = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 18)
+}
+
+func (x *ShellSession) SetCommand(v []string) {
+	x.xxx_hidden_Command = v
+}
+
+func (x *ShellSession) SetTerm(v string) {
+	x.xxx_hidden_Term = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 18)
+}
+
+func (x *ShellSession) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellSession) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellSession) HasUserName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellSession) HasRemoteAddr() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ShellSession) HasStart() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Start != nil
+}
+
+func (x *ShellSession) HasEnd() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_End != nil
+}
+
+func (x *ShellSession) HasWidth() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ShellSession) HasHeight() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *ShellSession) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *ShellSession) HasExitStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *ShellSession) HasTime() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *ShellSession) HasMemory() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *ShellSession) HasEvents() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *ShellSession) HasTruncated() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 13)
+}
+
+func (x *ShellSession) HasArchive() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 14)
+}
+
+func (x *ShellSession) HasProfile() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 15)
+}
+
+func (x *ShellSession) HasTerm() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 17)
+}
+
+func (x *ShellSession) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *ShellSession) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_UserId = nil
+}
+
+func (x *ShellSession) ClearUserName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_UserName = nil
+}
+
+func (x *ShellSession) ClearRemoteAddr() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_RemoteAddr = nil
+}
+
+func (x *ShellSession) ClearStart() {
+	x.xxx_hidden_Start = nil
+}
+
+func (x *ShellSession) ClearEnd() {
+	x.xxx_hidden_End = nil
+}
+
+func (x *ShellSession) ClearWidth() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Width = 0
+}
+
+func (x *ShellSession) ClearHeight() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Height = 0
+}
+
+func (x *ShellSession) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Status = nil
+}
+
+func (x *ShellSession) ClearExitStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_ExitStatus = 0
+}
+
+func (x *ShellSession) ClearTime() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_Time = 0
+}
+
+func (x *ShellSession) ClearMemory() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_Memory = 0
+}
+
+func (x *ShellSession) ClearEvents() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_Events = 0
+}
+
+func (x *ShellSession) ClearTruncated() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 13)
+	x.xxx_hidden_Truncated = false
+}
+
+func (x *ShellSession) ClearArchive() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 14)
+	x.xxx_hidden_Archive = nil
+}
+
+func (x *ShellSession) ClearProfile() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 15)
+	x.xxx_hidden_Profile = nil
+}
+
+func (x *ShellSession) ClearTerm() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 17)
+	x.xxx_hidden_Term = nil
+}
+
+type ShellSession_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id         *string
+	UserId     *string
+	UserName   *string
+	RemoteAddr *string
+	Start      *timestamppb.Timestamp
+	End        *timestamppb.Timestamp
+	Width      *uint32
+	Height     *uint32
+	Status     *string
+	ExitStatus *int32
+	Time       *uint64
+	Memory     *uint64
+	Events     *uint64
+	Truncated  *bool
+	Archive    *string
+	Profile    *string
+	Command    []string
+	Term       *string
+}
+
+func (b0 ShellSession_builder) Build() *ShellSession {
+	m0 := &ShellSession{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 18)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 18)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	if b.UserName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 18)
+		x.xxx_hidden_UserName = b.UserName
+	}
+	if b.RemoteAddr != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 18)
+		x.xxx_hidden_RemoteAddr = b.RemoteAddr
+	}
+	x.xxx_hidden_Start = b.Start
+	x.xxx_hidden_End = b.End
+	if b.Width != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 18)
+		x.xxx_hidden_Width = *b.Width
+	}
+	if b.Height != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 18)
+		x.xxx_hidden_Height = *b.Height
+	}
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 18)
+		x.xxx_hidden_Status = b.Status
+	}
+	if b.ExitStatus != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 18)
+		x.xxx_hidden_ExitStatus = *b.ExitStatus
+	}
+	if b.Time != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 18)
+		x.xxx_hidden_Time = *b.Time
+	}
+	if b.Memory != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 18)
+		x.xxx_hidden_Memory = *b.Memory
+	}
+	if b.Events != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 18)
+		x.xxx_hidden_Events = *b.Events
+	}
+	if b.Truncated != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 18)
+		x.xxx_hidden_Truncated = *b.Truncated
+	}
+	if b.Archive != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 18)
+		x.xxx_hidden_Archive = b.Archive
+	}
+	if b.Profile != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 18)
+		x.xxx_hidden_Profile = b.Profile
+	}
+	x.xxx_hidden_Command = b.Command
+	if b.Term != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 18)
+		x.xxx_hidden_Term = b.Term
+	}
+	return m0
+}
+
+type ListShellSessionsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,2,opt,name=userId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListShellSessionsRequest) Reset() {
+	*x = ListShellSessionsRequest{}
+	mi := &file_demo_backend_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellSessionsRequest) ProtoMessage() {}
+
+func (x *ListShellSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListShellSessionsRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ListShellSessionsRequest) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ListShellSessionsRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ListShellSessionsRequest) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ListShellSessionsRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListShellSessionsRequest) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ListShellSessionsRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *ListShellSessionsRequest) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_UserId = nil
+}
+
+type ListShellSessionsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id     *string
+	UserId *string
+}
+
+func (b0 ListShellSessionsRequest_builder) Build() *ListShellSessionsRequest {
+	m0 := &ListShellSessionsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	return m0
+}
+
+type ListShellSessionsResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Sessions *[]*ShellSession       `protobuf:"bytes,1,rep,name=sessions"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListShellSessionsResponse) Reset() {
+	*x = ListShellSessionsResponse{}
+	mi := &file_demo_backend_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellSessionsResponse) ProtoMessage() {}
+
+func (x *ListShellSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListShellSessionsResponse) GetSessions() []*ShellSession {
+	if x != nil {
+		if x.xxx_hidden_Sessions != nil {
+			return *x.xxx_hidden_Sessions
+		}
+	}
+	return nil
+}
+
+func (x *ListShellSessionsResponse) SetSessions(v []*ShellSession) {
+	x.xxx_hidden_Sessions = &v
+}
+
+type ListShellSessionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Sessions []*ShellSession
+}
+
+func (b0 ListShellSessionsResponse_builder) Build() *ListShellSessionsResponse {
+	m0 := &ListShellSessionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Sessions = &b.Sessions
+	return m0
+}
+
+type GetShellSessionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetShellSessionRequest) Reset() {
+	*x = GetShellSessionRequest{}
+	mi := &file_demo_backend_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShellSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShellSessionRequest) ProtoMessage() {}
+
+func (x *GetShellSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetShellSessionRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetShellSessionRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *GetShellSessionRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetShellSessionRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type GetShellSessionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 GetShellSessionRequest_builder) Build() *GetShellSessionRequest {
+	m0 := &GetShellSessionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+// ShellFile is uploaded by the caller and copied into the working directory of
+// the caller's new shells
+type ShellFile struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,2,opt,name=content"`
+	xxx_hidden_Size        uint64                 `protobuf:"varint,3,opt,name=size"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellFile) Reset() {
+	*x = ShellFile{}
+	mi := &file_demo_backend_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellFile) ProtoMessage() {}
+
+func (x *ShellFile) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellFile) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellFile) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *ShellFile) GetSize() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *ShellFile) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ShellFile) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ShellFile) SetSize(v uint64) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ShellFile) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellFile) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellFile) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellFile) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ShellFile) ClearContent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Content = nil
+}
+
+func (x *ShellFile) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Size = 0
+}
+
+type ShellFile_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name    *string
+	Content []byte
+	Size    *uint64
+}
+
+func (b0 ShellFile_builder) Build() *ShellFile {
+	m0 := &ShellFile{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Content != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Content = b.Content
+	}
+	if b.Size != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Size = *b.Size
+	}
+	return m0
+}
+
+type ListShellFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShellFilesRequest) Reset() {
+	*x = ListShellFilesRequest{}
+	mi := &file_demo_backend_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellFilesRequest) ProtoMessage() {}
+
+func (x *ListShellFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ListShellFilesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ListShellFilesRequest_builder) Build() *ListShellFilesRequest {
+	m0 := &ListShellFilesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ListShellFilesResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Files *[]*ShellFile          `protobuf:"bytes,1,rep,name=files"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListShellFilesResponse) Reset() {
+	*x = ListShellFilesResponse{}
+	mi := &file_demo_backend_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellFilesResponse) ProtoMessage() {}
+
+func (x *ListShellFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListShellFilesResponse) GetFiles() []*ShellFile {
+	if x != nil {
+		if x.xxx_hidden_Files != nil {
+			return *x.xxx_hidden_Files
+		}
+	}
+	return nil
+}
+
+func (x *ListShellFilesResponse) SetFiles(v []*ShellFile) {
+	x.xxx_hidden_Files = &v
+}
+
+type ListShellFilesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Files []*ShellFile
+}
+
+func (b0 ListShellFilesResponse_builder) Build() *ListShellFilesResponse {
+	m0 := &ListShellFilesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Files = &b.Files
+	return m0
+}
+
+type DeleteShellFileRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeleteShellFileRequest) Reset() {
+	*x = DeleteShellFileRequest{}
+	mi := &file_demo_backend_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShellFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShellFileRequest) ProtoMessage() {}
+
+func (x *DeleteShellFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteShellFileRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteShellFileRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *DeleteShellFileRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DeleteShellFileRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+type DeleteShellFileRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name *string
+}
+
+func (b0 DeleteShellFileRequest_builder) Build() *DeleteShellFileRequest {
+	m0 := &DeleteShellFileRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+type GetShellArchiveRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetShellArchiveRequest) Reset() {
+	*x = GetShellArchiveRequest{}
+	mi := &file_demo_backend_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShellArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShellArchiveRequest) ProtoMessage() {}
+
+func (x *GetShellArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetShellArchiveRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetShellArchiveRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *GetShellArchiveRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetShellArchiveRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type GetShellArchiveRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 GetShellArchiveRequest_builder) Build() *GetShellArchiveRequest {
+	m0 := &GetShellArchiveRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+// ShellWorkspace is the working directory saved when the user's shell exits and
+// restored in the next shell
+type ShellWorkspace struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Size        uint64                 `protobuf:"varint,1,opt,name=size"`
+	xxx_hidden_Updated     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated"`
+	xxx_hidden_Quota       uint64                 `protobuf:"varint,3,opt,name=quota"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellWorkspace) Reset() {
+	*x = ShellWorkspace{}
+	mi := &file_demo_backend_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellWorkspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellWorkspace) ProtoMessage() {}
+
+func (x *ShellWorkspace) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellWorkspace) GetSize() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *ShellWorkspace) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Updated
+	}
+	return nil
+}
+
+func (x *ShellWorkspace) GetQuota() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Quota
+	}
+	return 0
+}
+
+func (x *ShellWorkspace) SetSize(v uint64) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ShellWorkspace) SetUpdated(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Updated = v
+}
+
+func (x *ShellWorkspace) SetQuota(v uint64) {
+	x.xxx_hidden_Quota = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ShellWorkspace) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellWorkspace) HasUpdated() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Updated != nil
+}
+
+func (x *ShellWorkspace) HasQuota() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellWorkspace) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Size = 0
+}
+
+func (x *ShellWorkspace) ClearUpdated() {
+	x.xxx_hidden_Updated = nil
+}
+
+func (x *ShellWorkspace) ClearQuota() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Quota = 0
+}
+
+type ShellWorkspace_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Size    *uint64
+	Updated *timestamppb.Timestamp
+	Quota   *uint64
+}
+
+func (b0 ShellWorkspace_builder) Build() *ShellWorkspace {
+	m0 := &ShellWorkspace{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Size != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Size = *b.Size
+	}
+	x.xxx_hidden_Updated = b.Updated
+	if b.Quota != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Quota = *b.Quota
+	}
+	return m0
+}
+
+type GetShellWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShellWorkspaceRequest) Reset() {
+	*x = GetShellWorkspaceRequest{}
+	mi := &file_demo_backend_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShellWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShellWorkspaceRequest) ProtoMessage() {}
+
+func (x *GetShellWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetShellWorkspaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetShellWorkspaceRequest_builder) Build() *GetShellWorkspaceRequest {
+	m0 := &GetShellWorkspaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ResetShellWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetShellWorkspaceRequest) Reset() {
+	*x = ResetShellWorkspaceRequest{}
+	mi := &file_demo_backend_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetShellWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetShellWorkspaceRequest) ProtoMessage() {}
+
+func (x *ResetShellWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ResetShellWorkspaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ResetShellWorkspaceRequest_builder) Build() *ResetShellWorkspaceRequest {
+	m0 := &ResetShellWorkspaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+// ShellProfile is a shell configuration to start the session with
+type ShellProfile struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Description *string                `protobuf:"bytes,2,opt,name=description"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellProfile) Reset() {
+	*x = ShellProfile{}
+	mi := &file_demo_backend_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellProfile) ProtoMessage() {}
+
+func (x *ShellProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellProfile) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellProfile) GetDescription() string {
+	if x != nil {
+		if x.xxx_hidden_Description != nil {
+			return *x.xxx_hidden_Description
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellProfile) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ShellProfile) SetDescription(v string) {
+	x.xxx_hidden_Description = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ShellProfile) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellProfile) HasDescription() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellProfile) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ShellProfile) ClearDescription() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Description = nil
+}
+
+type ShellProfile_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name        *string
+	Description *string
+}
+
+func (b0 ShellProfile_builder) Build() *ShellProfile {
+	m0 := &ShellProfile{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Description != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Description = b.Description
+	}
+	return m0
+}
+
+type ListShellProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShellProfilesRequest) Reset() {
+	*x = ListShellProfilesRequest{}
+	mi := &file_demo_backend_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellProfilesRequest) ProtoMessage() {}
+
+func (x *ListShellProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ListShellProfilesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ListShellProfilesRequest_builder) Build() *ListShellProfilesRequest {
+	m0 := &ListShellProfilesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+// ListShellProfilesResponse lists the profiles, the first one is the default
+type ListShellProfilesResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Profiles *[]*ShellProfile       `protobuf:"bytes,1,rep,name=profiles"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListShellProfilesResponse) Reset() {
+	*x = ListShellProfilesResponse{}
+	mi := &file_demo_backend_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellProfilesResponse) ProtoMessage() {}
+
+func (x *ListShellProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListShellProfilesResponse) GetProfiles() []*ShellProfile {
+	if x != nil {
+		if x.xxx_hidden_Profiles != nil {
+			return *x.xxx_hidden_Profiles
+		}
+	}
+	return nil
+}
+
+func (x *ListShellProfilesResponse) SetProfiles(v []*ShellProfile) {
+	x.xxx_hidden_Profiles = &v
+}
+
+type ListShellProfilesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Profiles []*ShellProfile
+}
+
+func (b0 ListShellProfilesResponse_builder) Build() *ListShellProfilesResponse {
+	m0 := &ListShellProfilesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Profiles = &b.Profiles
+	return m0
+}
+
+// LiveShell is a running shell session, the CPU time used is only known after
+// the shell exited and recorded
+type LiveShell struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=sessionId"`
+	xxx_hidden_RecordId    *string                `protobuf:"bytes,2,opt,name=recordId"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,3,opt,name=userId"`
+	xxx_hidden_UserName    *string                `protobuf:"bytes,4,opt,name=userName"`
+	xxx_hidden_RemoteAddr  *string                `protobuf:"bytes,5,opt,name=remoteAddr"`
+	xxx_hidden_Profile     *string                `protobuf:"bytes,6,opt,name=profile"`
+	xxx_hidden_Start       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start"`
+	xxx_hidden_LastInput   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=lastInput"`
+	xxx_hidden_CpuLimit    uint64                 `protobuf:"varint,9,opt,name=cpuLimit"`
+	xxx_hidden_BytesIn     uint64                 `protobuf:"varint,10,opt,name=bytesIn"`
+	xxx_hidden_BytesOut    uint64                 `protobuf:"varint,11,opt,name=bytesOut"`
+	xxx_hidden_Clients     uint32                 `protobuf:"varint,12,opt,name=clients"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *LiveShell) Reset() {
+	*x = LiveShell{}
+	mi := &file_demo_backend_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveShell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveShell) ProtoMessage() {}
+
+func (x *LiveShell) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *LiveShell) GetSessionId() string {
+	if x != nil {
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LiveShell) GetRecordId() string {
+	if x != nil {
+		if x.xxx_hidden_RecordId != nil {
+			return *x.xxx_hidden_RecordId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LiveShell) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LiveShell) GetUserName() string {
+	if x != nil {
+		if x.xxx_hidden_UserName != nil {
+			return *x.xxx_hidden_UserName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LiveShell) GetRemoteAddr() string {
+	if x != nil {
+		if x.xxx_hidden_RemoteAddr != nil {
+			return *x.xxx_hidden_RemoteAddr
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LiveShell) GetProfile() string {
+	if x != nil {
+		if x.xxx_hidden_Profile != nil {
+			return *x.xxx_hidden_Profile
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LiveShell) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Start
+	}
+	return nil
+}
+
+func (x *LiveShell) GetLastInput() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_LastInput
+	}
+	return nil
+}
+
+func (x *LiveShell) GetCpuLimit() uint64 {
+	if x != nil {
+		return x.xxx_hidden_CpuLimit
+	}
+	return 0
+}
+
+func (x *LiveShell) GetBytesIn() uint64 {
+	if x != nil {
+		return x.xxx_hidden_BytesIn
+	}
+	return 0
+}
+
+func (x *LiveShell) GetBytesOut() uint64 {
+	if x != nil {
+		return x.xxx_hidden_BytesOut
+	}
+	return 0
+}
+
+func (x *LiveShell) GetClients() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Clients
+	}
+	return 0
+}
+
+func (x *LiveShell) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 12)
+}
+
+func (x *LiveShell) SetRecordId(v string) {
+	x.xxx_hidden_RecordId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 12)
+}
+
+func (x *LiveShell) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 12)
+}
+
+func (x *LiveShell) SetUserName(v string) {
+	x.xxx_hidden_UserName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 12)
+}
+
+func (x *LiveShell) SetRemoteAddr(v string) {
+	x.xxx_hidden_RemoteAddr = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 12)
+}
+
+func (x *LiveShell) SetProfile(v string) {
+	x.xxx_hidden_Profile = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 12)
+}
+
+func (x *LiveShell) SetStart(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Start = v
+}
+
+func (x *LiveShell) SetLastInput(v *timestamppb.Timestamp) {
+	x.xxx_hidden_LastInput = v
+}
+
+func (x *LiveShell) SetCpuLimit(v uint64) {
+	x.xxx_hidden_CpuLimit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 12)
+}
+
+func (x *LiveShell) SetBytesIn(v uint64) {
+	x.xxx_hidden_BytesIn = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 12)
+}
+
+func (x *LiveShell) SetBytesOut(v uint64) {
+	x.xxx_hidden_BytesOut = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 12)
+}
+
+func (x *LiveShell) SetClients(v uint32) {
+	x.xxx_hidden_Clients = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 12)
+}
+
+func (x *LiveShell) HasSessionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *LiveShell) HasRecordId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *LiveShell) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *LiveShell) HasUserName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *LiveShell) HasRemoteAddr() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *LiveShell) HasProfile() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *LiveShell) HasStart() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Start != nil
+}
+
+func (x *LiveShell) HasLastInput() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_LastInput != nil
+}
+
+func (x *LiveShell) HasCpuLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *LiveShell) HasBytesIn() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *LiveShell) HasBytesOut() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *LiveShell) HasClients() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *LiveShell) ClearSessionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SessionId = nil
+}
+
+func (x *LiveShell) ClearRecordId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_RecordId = nil
+}
+
+func (x *LiveShell) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_UserId = nil
+}
+
+func (x *LiveShell) ClearUserName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_UserName = nil
+}
+
+func (x *LiveShell) ClearRemoteAddr() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_RemoteAddr = nil
+}
+
+func (x *LiveShell) ClearProfile() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Profile = nil
+}
+
+func (x *LiveShell) ClearStart() {
+	x.xxx_hidden_Start = nil
+}
+
+func (x *LiveShell) ClearLastInput() {
+	x.xxx_hidden_LastInput = nil
+}
+
+func (x *LiveShell) ClearCpuLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_CpuLimit = 0
+}
+
+func (x *LiveShell) ClearBytesIn() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_BytesIn = 0
+}
+
+func (x *LiveShell) ClearBytesOut() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_BytesOut = 0
+}
+
+func (x *LiveShell) ClearClients() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_Clients = 0
+}
+
+type LiveShell_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	SessionId  *string
+	RecordId   *string
+	UserId     *string
+	UserName   *string
+	RemoteAddr *string
+	Profile    *string
+	Start      *timestamppb.Timestamp
+	LastInput  *timestamppb.Timestamp
+	CpuLimit   *uint64
+	BytesIn    *uint64
+	BytesOut   *uint64
+	Clients    *uint32
+}
+
+func (b0 LiveShell_builder) Build() *LiveShell {
+	m0 := &LiveShell{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 12)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	if b.RecordId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 12)
+		x.xxx_hidden_RecordId = b.RecordId
+	}
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 12)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	if b.UserName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 12)
+		x.xxx_hidden_UserName = b.UserName
+	}
+	if b.RemoteAddr != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 12)
+		x.xxx_hidden_RemoteAddr = b.RemoteAddr
+	}
+	if b.Profile != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 12)
+		x.xxx_hidden_Profile = b.Profile
+	}
+	x.xxx_hidden_Start = b.Start
+	x.xxx_hidden_LastInput = b.LastInput
+	if b.CpuLimit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 12)
+		x.xxx_hidden_CpuLimit = *b.CpuLimit
+	}
+	if b.BytesIn != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 12)
+		x.xxx_hidden_BytesIn = *b.BytesIn
+	}
+	if b.BytesOut != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 12)
+		x.xxx_hidden_BytesOut = *b.BytesOut
+	}
+	if b.Clients != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 12)
+		x.xxx_hidden_Clients = *b.Clients
+	}
+	return m0
+}
+
+type ListLiveShellsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,1,opt,name=userId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListLiveShellsRequest) Reset() {
+	*x = ListLiveShellsRequest{}
+	mi := &file_demo_backend_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLiveShellsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLiveShellsRequest) ProtoMessage() {}
+
+func (x *ListLiveShellsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListLiveShellsRequest) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ListLiveShellsRequest) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ListLiveShellsRequest) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListLiveShellsRequest) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_UserId = nil
+}
+
+type ListLiveShellsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	UserId *string
+}
+
+func (b0 ListLiveShellsRequest_builder) Build() *ListLiveShellsRequest {
+	m0 := &ListLiveShellsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	return m0
+}
+
+type ListLiveShellsResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Shells *[]*LiveShell          `protobuf:"bytes,1,rep,name=shells"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListLiveShellsResponse) Reset() {
+	*x = ListLiveShellsResponse{}
+	mi := &file_demo_backend_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLiveShellsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLiveShellsResponse) ProtoMessage() {}
+
+func (x *ListLiveShellsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListLiveShellsResponse) GetShells() []*LiveShell {
+	if x != nil {
+		if x.xxx_hidden_Shells != nil {
+			return *x.xxx_hidden_Shells
+		}
+	}
+	return nil
+}
+
+func (x *ListLiveShellsResponse) SetShells(v []*LiveShell) {
+	x.xxx_hidden_Shells = &v
+}
+
+type ListLiveShellsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Shells []*LiveShell
+}
+
+func (b0 ListLiveShellsResponse_builder) Build() *ListLiveShellsResponse {
+	m0 := &ListLiveShellsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Shells = &b.Shells
+	return m0
+}
+
+type KillShellRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=sessionId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *KillShellRequest) Reset() {
+	*x = KillShellRequest{}
+	mi := &file_demo_backend_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KillShellRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillShellRequest) ProtoMessage() {}
+
+func (x *KillShellRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *KillShellRequest) GetSessionId() string {
+	if x != nil {
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *KillShellRequest) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *KillShellRequest) HasSessionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *KillShellRequest) ClearSessionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SessionId = nil
+}
+
+type KillShellRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	SessionId *string
+}
+
+func (b0 KillShellRequest_builder) Build() *KillShellRequest {
+	m0 := &KillShellRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	return m0
+}
+
+// ShellEvent is an asciicast v2 event
+type ShellEvent struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Time        float64                `protobuf:"fixed64,1,opt,name=time"`
+	xxx_hidden_Type        *string                `protobuf:"bytes,2,opt,name=type"`
+	xxx_hidden_Data        *string                `protobuf:"bytes,3,opt,name=data"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ShellEvent) Reset() {
+	*x = ShellEvent{}
+	mi := &file_demo_backend_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellEvent) ProtoMessage() {}
+
+func (x *ShellEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellEvent) GetTime() float64 {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return 0
+}
+
+func (x *ShellEvent) GetType() string {
+	if x != nil {
+		if x.xxx_hidden_Type != nil {
+			return *x.xxx_hidden_Type
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellEvent) GetData() string {
+	if x != nil {
+		if x.xxx_hidden_Data != nil {
+			return *x.xxx_hidden_Data
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShellEvent) SetTime(v float64) {
+	x.xxx_hidden_Time = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ShellEvent) SetType(v string) {
+	x.xxx_hidden_Type = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ShellEvent) SetData(v string) {
+	x.xxx_hidden_Data = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ShellEvent) HasTime() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShellEvent) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShellEvent) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShellEvent) ClearTime() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Time = 0
+}
+
+func (x *ShellEvent) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Type = nil
+}
+
+func (x *ShellEvent) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Data = nil
+}
+
+type ShellEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Time *float64
+	Type *string
+	Data *string
+}
+
+func (b0 ShellEvent_builder) Build() *ShellEvent {
+	m0 := &ShellEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Time != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Time = *b.Time
+	}
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Type = b.Type
+	}
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Data = b.Data
+	}
+	return m0
+}
+
+// ShellSessionChunk streams the session, the first chunk carries the metadata
+type ShellSessionChunk struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Session *ShellSession          `protobuf:"bytes,1,opt,name=session"`
+	xxx_hidden_Events  *[]*ShellEvent         `protobuf:"bytes,2,rep,name=events"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ShellSessionChunk) Reset() {
+	*x = ShellSessionChunk{}
+	mi := &file_demo_backend_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellSessionChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellSessionChunk) ProtoMessage() {}
+
+func (x *ShellSessionChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_demo_backend_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShellSessionChunk) GetSession() *ShellSession {
+	if x != nil {
+		return x.xxx_hidden_Session
+	}
+	return nil
+}
+
+func (x *ShellSessionChunk) GetEvents() []*ShellEvent {
+	if x != nil {
+		if x.xxx_hidden_Events != nil {
+			return *x.xxx_hidden_Events
+		}
+	}
+	return nil
+}
+
+func (x *ShellSessionChunk) SetSession(v *ShellSession) {
+	x.xxx_hidden_Session = v
+}
+
+func (x *ShellSessionChunk) SetEvents(v []*ShellEvent) {
+	x.xxx_hidden_Events = &v
+}
+
+func (x *ShellSessionChunk) HasSession() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Session != nil
+}
+
+func (x *ShellSessionChunk) ClearSession() {
+	x.xxx_hidden_Session = nil
+}
+
+type ShellSessionChunk_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Session *ShellSession
+	Events  []*ShellEvent
+}
+
+func (b0 ShellSessionChunk_builder) Build() *ShellSessionChunk {
+	m0 := &ShellSessionChunk{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Session = b.Session
+	x.xxx_hidden_Events = &b.Events
+	return m0
+}
+
 var File_demo_backend_proto protoreflect.FileDescriptor
 
-var file_demo_backend_proto_rawDesc = string([]byte{
-	0x0a, 0x12, 0x64, 0x65, 0x6d, 0x6f, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x6f, 0x5f, 0x66, 0x65, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x23, 0x0a, 0x11, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x46,
-	0x0a, 0x12, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x53,
-	0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x88, 0x02, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e,
-	0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x24, 0x0a, 0x07, 0x72,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70,
-	0x62, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x22, 0xa0, 0x01, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6d, 0x70, 0x69, 0x6c, 0x65, 0x43, 0x6d, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x43, 0x6d, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x75, 0x6e, 0x43, 0x6d, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x75,
-	0x6e, 0x43, 0x6d, 0x64, 0x22, 0x8c, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74,
-	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73,
-	0x74, 0x64, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69,
-	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
-	0x65, 0x72, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72,
-	0x72, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6c, 0x6f, 0x67, 0x22, 0x3b, 0x0a, 0x0b, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x22, 0x84, 0x01, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x28, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61,
-	0x67, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x49,
-	0x6e, 0x70, 0x75, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75,
-	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x20, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x6d, 0x69,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xe1, 0x01, 0x0a, 0x0b, 0x4a, 0x75,
-	0x64, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e,
-	0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
-	0x24, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x99, 0x01,
-	0x0a, 0x12, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e, 0x67,
-	0x75, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62,
-	0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x0b, 0x69, 0x6e,
-	0x70, 0x75, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0xe9, 0x01, 0x0a, 0x13, 0x4a, 0x75,
-	0x64, 0x67, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a,
-	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c,
-	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x21, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x4c, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x69,
-	0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x01, 0x79, 0x22, 0x60, 0x0a, 0x0a, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x49,
-	0x6e, 0x70, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00,
-	0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x7a,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
-	0x69, 0x7a, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x09, 0x0a,
-	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x27, 0x0a, 0x0b, 0x53, 0x68, 0x65, 0x6c,
-	0x6c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x32, 0x9d, 0x02, 0x0a, 0x0b, 0x44, 0x65, 0x6d, 0x6f, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
-	0x64, 0x12, 0x3b, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
-	0x0a, 0x06, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75,
-	0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x62,
-	0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x34, 0x0a, 0x07, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x05, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x12, 0x17,
-	0x2e, 0x70, 0x62, 0x2e, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x4a, 0x75, 0x64,
-	0x67, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x28,
-	0x01, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x0e, 0x2e, 0x70,
-	0x62, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x0f, 0x2e, 0x70,
-	0x62, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x28, 0x01, 0x30,
-	0x01, 0x42, 0x2c, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x63, 0x72, 0x69, 0x79, 0x6c, 0x65, 0x2f, 0x67, 0x6f, 0x2d, 0x6a, 0x75, 0x64, 0x67, 0x65, 0x2d,
-	0x64, 0x65, 0x6d, 0x6f, 0x2f, 0x70, 0x62, 0x92, 0x03, 0x05, 0xd2, 0x3e, 0x02, 0x10, 0x03, 0x62,
-	0x08, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0xe8, 0x07,
-})
-
-var file_demo_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+const file_demo_backend_proto_rawDesc = "" +
+	"\n" +
+	"\x12demo_backend.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!google/protobuf/go_features.proto\"#\n" +
+	"\x11SubmissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x12SubmissionResponse\x120\n" +
+	"\vsubmissions\x18\x01 \x03(\v2\x0e.pb.SubmissionR\vsubmissions\"&\n" +
+	"\x14GetSubmissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x94\x03\n" +
+	"\n" +
+	"Submission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\blanguage\x18\x02 \x01(\v2\f.pb.LanguageR\blanguage\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1c\n" +
+	"\ttotalTime\x18\x06 \x01(\x04R\ttotalTime\x12\x1c\n" +
+	"\tmaxMemory\x18\a \x01(\x04R\tmaxMemory\x12$\n" +
+	"\aresults\x18\b \x03(\v2\n" +
+	".pb.ResultR\aresults\x12$\n" +
+	"\x05files\x18\t \x03(\v2\x0e.pb.SourceFileR\x05files\x12\x1c\n" +
+	"\tproblemId\x18\n" +
+	" \x01(\tR\tproblemId\x12\x16\n" +
+	"\x06userId\x18\v \x01(\tR\x06userId\x12\x1a\n" +
+	"\buserName\x18\f \x01(\tR\buserName\x12\x12\n" +
+	"\x04type\x18\r \x01(\tR\x04type\"\xa0\x01\n" +
+	"\bLanguage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
+	"\x0esourceFileName\x18\x02 \x01(\tR\x0esourceFileName\x12\x1e\n" +
+	"\n" +
+	"compileCmd\x18\x03 \x01(\tR\n" +
+	"compileCmd\x12 \n" +
+	"\vexecutables\x18\x04 \x01(\tR\vexecutables\x12\x16\n" +
+	"\x06runCmd\x18\x05 \x01(\tR\x06runCmd\"\x8c\x01\n" +
+	"\x06Result\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x04R\x04time\x12\x16\n" +
+	"\x06memory\x18\x02 \x01(\x04R\x06memory\x12\x14\n" +
+	"\x05stdin\x18\x03 \x01(\tR\x05stdin\x12\x16\n" +
+	"\x06stdout\x18\x04 \x01(\tR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x05 \x01(\tR\x06stderr\x12\x10\n" +
+	"\x03log\x18\x06 \x01(\tR\x03log\"y\n" +
+	"\vInputAnswer\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\x16\n" +
+	"\x06answer\x18\x02 \x01(\tR\x06answer\x12\x1c\n" +
+	"\tinputHash\x18\x03 \x01(\tR\tinputHash\x12\x1e\n" +
+	"\n" +
+	"answerHash\x18\x04 \x01(\tR\n" +
+	"answerHash\":\n" +
+	"\n" +
+	"SourceFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"\xf2\x01\n" +
+	"\rSubmitRequest\x12(\n" +
+	"\blanguage\x18\x01 \x01(\v2\f.pb.LanguageR\blanguage\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x121\n" +
+	"\vinputAnswer\x18\x03 \x03(\v2\x0f.pb.InputAnswerR\vinputAnswer\x12$\n" +
+	"\x05files\x18\x04 \x03(\v2\x0e.pb.SourceFileR\x05files\x12\x1c\n" +
+	"\tproblemId\x18\x05 \x01(\tR\tproblemId\x12(\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\f.pb.PriorityR\bpriority\"Q\n" +
+	"\n" +
+	"RunRequest\x12)\n" +
+	"\x06submit\x18\x01 \x01(\v2\x11.pb.SubmitRequestR\x06submit\x12\x18\n" +
+	"\atimeout\x18\x02 \x01(\rR\atimeout\"F\n" +
+	"\x0eSubmitResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\rqueuePosition\x18\x02 \x01(\rR\rqueuePosition\"\xb1\x02\n" +
+	"\vJudgeUpdate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12(\n" +
+	"\blanguage\x18\x05 \x01(\v2\f.pb.LanguageR\blanguage\x12$\n" +
+	"\aresults\x18\x06 \x03(\v2\n" +
+	".pb.ResultR\aresults\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12$\n" +
+	"\rqueuePosition\x18\b \x01(\rR\rqueuePosition\x12\x16\n" +
+	"\x06userId\x18\t \x01(\tR\x06userId\x12\x10\n" +
+	"\x03seq\x18\n" +
+	" \x01(\x04R\x03seq\"l\n" +
+	"\x0eUpdatesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\x04R\x05since\"\xe3\x02\n" +
+	"\x12JudgeClientRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\blanguage\x18\x02 \x01(\v2\f.pb.LanguageR\blanguage\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x121\n" +
+	"\vinputAnswer\x18\x04 \x03(\v2\x0f.pb.InputAnswerR\vinputAnswer\x12$\n" +
+	"\x05files\x18\x05 \x03(\v2\x0e.pb.SourceFileR\x05files\x12\x1c\n" +
+	"\ttimeLimit\x18\x06 \x01(\x04R\ttimeLimit\x12 \n" +
+	"\vmemoryLimit\x18\a \x01(\x04R\vmemoryLimit\x12%\n" +
+	"\achecker\x18\b \x01(\v2\v.pb.CheckerR\achecker\x12;\n" +
+	"\fbuildProblem\x18\t \x01(\v2\x17.pb.BuildProblemRequestR\fbuildProblem\"\xae\x02\n" +
+	"\x13JudgeClientResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12(\n" +
+	"\blanguage\x18\x05 \x01(\v2\f.pb.LanguageR\blanguage\x12$\n" +
+	"\aresults\x18\x06 \x03(\v2\n" +
+	".pb.ResultR\aresults\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x121\n" +
+	"\vinputAnswer\x18\b \x03(\v2\x0f.pb.InputAnswerR\vinputAnswer\x12\x10\n" +
+	"\x03log\x18\t \x01(\tR\x03log\"!\n" +
+	"\x05Input\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\"L\n" +
+	"\x06Resize\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\rR\x04rows\x12\x12\n" +
+	"\x04cols\x18\x02 \x01(\rR\x04cols\x12\f\n" +
+	"\x01x\x18\x03 \x01(\rR\x01x\x12\f\n" +
+	"\x01y\x18\x04 \x01(\rR\x01y\"\xe4\x01\n" +
+	"\n" +
+	"ShellInput\x12!\n" +
+	"\x05input\x18\x01 \x01(\v2\t.pb.InputH\x00R\x05input\x12$\n" +
+	"\x06resize\x18\x02 \x01(\v2\n" +
+	".pb.ResizeH\x00R\x06resize\x12)\n" +
+	"\x06attach\x18\x03 \x01(\v2\x0f.pb.ShellAttachH\x00R\x06attach\x12&\n" +
+	"\x05share\x18\x04 \x01(\v2\x0e.pb.ShellShareH\x00R\x05share\x12/\n" +
+	"\btransfer\x18\x05 \x01(\v2\x11.pb.ShellTransferH\x00R\btransferB\t\n" +
+	"\arequest\"\x89\x01\n" +
+	"\vShellAttach\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05write\x18\x02 \x01(\bR\x05write\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x04R\x06offset\x12\x18\n" +
+	"\aprofile\x18\x05 \x01(\tR\aprofile\"\"\n" +
+	"\n" +
+	"ShellShare\x12\x14\n" +
+	"\x05write\x18\x01 \x01(\bR\x05write\"o\n" +
+	"\rShellTransfer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bdownload\x18\x02 \x01(\bR\bdownload\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x8f\x02\n" +
+	"\vShellOutput\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x1c\n" +
+	"\tsessionId\x18\x03 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05write\x18\x04 \x01(\bR\x05write\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x04R\x06offset\x12\x1a\n" +
+	"\brecordId\x18\a \x01(\tR\brecordId\x12!\n" +
+	"\x04exit\x18\b \x01(\v2\r.pb.ShellExitR\x04exit\x12\x16\n" +
+	"\x06notice\x18\t \x01(\tR\x06notice\x12-\n" +
+	"\btransfer\x18\n" +
+	" \x01(\v2\x11.pb.ShellTransferR\btransfer\"o\n" +
+	"\tShellExit\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"exitStatus\x18\x02 \x01(\x05R\n" +
+	"exitStatus\x12\x12\n" +
+	"\x04time\x18\x03 \x01(\x04R\x04time\x12\x16\n" +
+	"\x06memory\x18\x04 \x01(\x04R\x06memory\"q\n" +
+	"\aChecker\x12(\n" +
+	"\blanguage\x18\x01 \x01(\v2\f.pb.LanguageR\blanguage\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12$\n" +
+	"\x05files\x18\x03 \x03(\v2\x0e.pb.SourceFileR\x05files\"\xd1\x02\n" +
+	"\aProblem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1c\n" +
+	"\tstatement\x18\x03 \x01(\tR\tstatement\x12\x1c\n" +
+	"\ttimeLimit\x18\x04 \x01(\x04R\ttimeLimit\x12 \n" +
+	"\vmemoryLimit\x18\x05 \x01(\x04R\vmemoryLimit\x12%\n" +
+	"\achecker\x18\x06 \x01(\v2\v.pb.CheckerR\achecker\x12-\n" +
+	"\ttestCases\x18\a \x03(\v2\x0f.pb.InputAnswerR\ttestCases\x12.\n" +
+	"\x04date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12 \n" +
+	"\vbuildStatus\x18\t \x01(\tR\vbuildStatus\x12\x1a\n" +
+	"\bbuildLog\x18\n" +
+	" \x01(\tR\bbuildLog\"?\n" +
+	"\x11GetProblemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\btestData\x18\x02 \x01(\bR\btestData\"%\n" +
+	"\x13ListProblemsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x14ListProblemsResponse\x12'\n" +
+	"\bproblems\x18\x01 \x03(\v2\v.pb.ProblemR\bproblems\"@\n" +
+	"\x14ImportProblemRequest\x12\x18\n" +
+	"\apackage\x18\x01 \x01(\fR\apackage\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x85\x01\n" +
+	"\aProgram\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
+	"\blanguage\x18\x02 \x01(\v2\f.pb.LanguageR\blanguage\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12$\n" +
+	"\x05files\x18\x04 \x03(\v2\x0e.pb.SourceFileR\x05files\"\xcc\x01\n" +
+	"\x13BuildProblemRequest\x12\x1c\n" +
+	"\tproblemId\x18\x01 \x01(\tR\tproblemId\x12+\n" +
+	"\n" +
+	"generators\x18\x02 \x03(\v2\v.pb.ProgramR\n" +
+	"generators\x12)\n" +
+	"\tvalidator\x18\x03 \x01(\v2\v.pb.ProgramR\tvalidator\x12'\n" +
+	"\bsolution\x18\x04 \x01(\v2\v.pb.ProgramR\bsolution\x12\x16\n" +
+	"\x06script\x18\x05 \x01(\tR\x06script\"&\n" +
+	"\x14BuildProblemResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x10FetchBlobRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"9\n" +
+	"\tBlobChunk\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x04R\x04size\"\x94\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\fpasswordHash\x18\x03 \x01(\fR\fpasswordHash\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12.\n" +
+	"\x04date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"4\n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"C\n" +
+	"\x11VerifyUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xfc\x03\n" +
+	"\fShellSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\buserName\x18\x03 \x01(\tR\buserName\x12\x1e\n" +
+	"\n" +
+	"remoteAddr\x18\x04 \x01(\tR\n" +
+	"remoteAddr\x120\n" +
+	"\x05start\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05width\x18\a \x01(\rR\x05width\x12\x16\n" +
+	"\x06height\x18\b \x01(\rR\x06height\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"exitStatus\x18\n" +
+	" \x01(\x05R\n" +
+	"exitStatus\x12\x12\n" +
+	"\x04time\x18\v \x01(\x04R\x04time\x12\x16\n" +
+	"\x06memory\x18\f \x01(\x04R\x06memory\x12\x16\n" +
+	"\x06events\x18\r \x01(\x04R\x06events\x12\x1c\n" +
+	"\ttruncated\x18\x0e \x01(\bR\ttruncated\x12\x18\n" +
+	"\aarchive\x18\x0f \x01(\tR\aarchive\x12\x18\n" +
+	"\aprofile\x18\x10 \x01(\tR\aprofile\x12\x18\n" +
+	"\acommand\x18\x11 \x03(\tR\acommand\x12\x12\n" +
+	"\x04term\x18\x12 \x01(\tR\x04term\"B\n" +
+	"\x18ListShellSessionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\"I\n" +
+	"\x19ListShellSessionsResponse\x12,\n" +
+	"\bsessions\x18\x01 \x03(\v2\x10.pb.ShellSessionR\bsessions\"(\n" +
+	"\x16GetShellSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\tShellFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x04R\x04size\"\x17\n" +
+	"\x15ListShellFilesRequest\"=\n" +
+	"\x16ListShellFilesResponse\x12#\n" +
+	"\x05files\x18\x01 \x03(\v2\r.pb.ShellFileR\x05files\",\n" +
+	"\x16DeleteShellFileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"(\n" +
+	"\x16GetShellArchiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"p\n" +
+	"\x0eShellWorkspace\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x04R\x04size\x124\n" +
+	"\aupdated\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\x12\x14\n" +
+	"\x05quota\x18\x03 \x01(\x04R\x05quota\"\x1a\n" +
+	"\x18GetShellWorkspaceRequest\"\x1c\n" +
+	"\x1aResetShellWorkspaceRequest\"D\n" +
+	"\fShellProfile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"\x1a\n" +
+	"\x18ListShellProfilesRequest\"I\n" +
+	"\x19ListShellProfilesResponse\x12,\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x10.pb.ShellProfileR\bprofiles\"\x8b\x03\n" +
+	"\tLiveShell\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\brecordId\x18\x02 \x01(\tR\brecordId\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\buserName\x18\x04 \x01(\tR\buserName\x12\x1e\n" +
+	"\n" +
+	"remoteAddr\x18\x05 \x01(\tR\n" +
+	"remoteAddr\x12\x18\n" +
+	"\aprofile\x18\x06 \x01(\tR\aprofile\x120\n" +
+	"\x05start\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x128\n" +
+	"\tlastInput\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tlastInput\x12\x1a\n" +
+	"\bcpuLimit\x18\t \x01(\x04R\bcpuLimit\x12\x18\n" +
+	"\abytesIn\x18\n" +
+	" \x01(\x04R\abytesIn\x12\x1a\n" +
+	"\bbytesOut\x18\v \x01(\x04R\bbytesOut\x12\x18\n" +
+	"\aclients\x18\f \x01(\rR\aclients\"/\n" +
+	"\x15ListLiveShellsRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"?\n" +
+	"\x16ListLiveShellsResponse\x12%\n" +
+	"\x06shells\x18\x01 \x03(\v2\r.pb.LiveShellR\x06shells\"0\n" +
+	"\x10KillShellRequest\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\tR\tsessionId\"H\n" +
+	"\n" +
+	"ShellEvent\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x01R\x04time\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\"g\n" +
+	"\x11ShellSessionChunk\x12*\n" +
+	"\asession\x18\x01 \x01(\v2\x10.pb.ShellSessionR\asession\x12&\n" +
+	"\x06events\x18\x02 \x03(\v2\x0e.pb.ShellEventR\x06events*y\n" +
+	"\bPriority\x12\x14\n" +
+	"\x10PRIORITY_DEFAULT\x10\x00\x12\x18\n" +
+	"\x14PRIORITY_INTERACTIVE\x10\x01\x12\x14\n" +
+	"\x10PRIORITY_CONTEST\x10\x02\x12\x14\n" +
+	"\x10PRIORITY_REJUDGE\x10\x03\x12\x11\n" +
+	"\rPRIORITY_BULK\x10\x042\xfb\f\n" +
+	"\vDemoBackend\x12;\n" +
+	"\n" +
+	"Submission\x12\x15.pb.SubmissionRequest\x1a\x16.pb.SubmissionResponse\x129\n" +
+	"\rGetSubmission\x12\x18.pb.GetSubmissionRequest\x1a\x0e.pb.Submission\x12/\n" +
+	"\x06Submit\x12\x11.pb.SubmitRequest\x1a\x12.pb.SubmitResponse\x120\n" +
+	"\aUpdates\x12\x12.pb.UpdatesRequest\x1a\x0f.pb.JudgeUpdate0\x01\x12&\n" +
+	"\x03Run\x12\x0e.pb.RunRequest\x1a\x0f.pb.JudgeUpdate\x12<\n" +
+	"\x05Judge\x12\x17.pb.JudgeClientResponse\x1a\x16.pb.JudgeClientRequest(\x010\x01\x12,\n" +
+	"\x05Shell\x12\x0e.pb.ShellInput\x1a\x0f.pb.ShellOutput(\x010\x01\x12)\n" +
+	"\rCreateProblem\x12\v.pb.Problem\x1a\v.pb.Problem\x120\n" +
+	"\n" +
+	"GetProblem\x12\x15.pb.GetProblemRequest\x1a\v.pb.Problem\x12A\n" +
+	"\fListProblems\x12\x17.pb.ListProblemsRequest\x1a\x18.pb.ListProblemsResponse\x12)\n" +
+	"\rUpdateProblem\x12\v.pb.Problem\x1a\v.pb.Problem\x122\n" +
+	"\tFetchBlob\x12\x14.pb.FetchBlobRequest\x1a\r.pb.BlobChunk0\x01\x126\n" +
+	"\rImportProblem\x12\x18.pb.ImportProblemRequest\x1a\v.pb.Problem\x12A\n" +
+	"\fBuildProblem\x12\x17.pb.BuildProblemRequest\x1a\x18.pb.BuildProblemResponse\x12 \n" +
+	"\n" +
+	"CreateUser\x12\b.pb.User\x1a\b.pb.User\x12'\n" +
+	"\aGetUser\x12\x12.pb.GetUserRequest\x1a\b.pb.User\x12-\n" +
+	"\n" +
+	"VerifyUser\x12\x15.pb.VerifyUserRequest\x1a\b.pb.User\x12P\n" +
+	"\x11ListShellSessions\x12\x1c.pb.ListShellSessionsRequest\x1a\x1d.pb.ListShellSessionsResponse\x12F\n" +
+	"\x0fGetShellSession\x12\x1a.pb.GetShellSessionRequest\x1a\x15.pb.ShellSessionChunk0\x01\x12<\n" +
+	"\x0fUploadShellFile\x12\r.pb.ShellFile\x1a\x1a.pb.ListShellFilesResponse\x12G\n" +
+	"\x0eListShellFiles\x12\x19.pb.ListShellFilesRequest\x1a\x1a.pb.ListShellFilesResponse\x12I\n" +
+	"\x0fDeleteShellFile\x12\x1a.pb.DeleteShellFileRequest\x1a\x1a.pb.ListShellFilesResponse\x12>\n" +
+	"\x0fGetShellArchive\x12\x1a.pb.GetShellArchiveRequest\x1a\r.pb.BlobChunk0\x01\x12E\n" +
+	"\x11GetShellWorkspace\x12\x1c.pb.GetShellWorkspaceRequest\x1a\x12.pb.ShellWorkspace\x12I\n" +
+	"\x13ResetShellWorkspace\x12\x1e.pb.ResetShellWorkspaceRequest\x1a\x12.pb.ShellWorkspace\x12P\n" +
+	"\x11ListShellProfiles\x12\x1c.pb.ListShellProfilesRequest\x1a\x1d.pb.ListShellProfilesResponse\x12G\n" +
+	"\x0eListLiveShells\x12\x19.pb.ListLiveShellsRequest\x1a\x1a.pb.ListLiveShellsResponse\x120\n" +
+	"\tKillShell\x12\x14.pb.KillShellRequest\x1a\r.pb.LiveShellB,Z\"github.com/criyle/go-judge-demo/pb\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
+
+var file_demo_backend_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_demo_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_demo_backend_proto_goTypes = []any{
-	(*SubmissionRequest)(nil),     // 0: pb.SubmissionRequest
-	(*SubmissionResponse)(nil),    // 1: pb.SubmissionResponse
-	(*Submission)(nil),            // 2: pb.Submission
-	(*Language)(nil),              // 3: pb.Language
-	(*Result)(nil),                // 4: pb.Result
-	(*InputAnswer)(nil),           // 5: pb.InputAnswer
-	(*SubmitRequest)(nil),         // 6: pb.SubmitRequest
-	(*SubmitResponse)(nil),        // 7: pb.SubmitResponse
-	(*JudgeUpdate)(nil),           // 8: pb.JudgeUpdate
-	(*JudgeClientRequest)(nil),    // 9: pb.JudgeClientRequest
-	(*JudgeClientResponse)(nil),   // 10: pb.JudgeClientResponse
-	(*Input)(nil),                 // 11: pb.Input
-	(*Resize)(nil),                // 12: pb.Resize
-	(*ShellInput)(nil),            // 13: pb.ShellInput
-	(*ShellOutput)(nil),           // 14: pb.ShellOutput
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 16: google.protobuf.Empty
+	(Priority)(0),                      // 0: pb.Priority
+	(*SubmissionRequest)(nil),          // 1: pb.SubmissionRequest
+	(*SubmissionResponse)(nil),         // 2: pb.SubmissionResponse
+	(*GetSubmissionRequest)(nil),       // 3: pb.GetSubmissionRequest
+	(*Submission)(nil),                 // 4: pb.Submission
+	(*Language)(nil),                   // 5: pb.Language
+	(*Result)(nil),                     // 6: pb.Result
+	(*InputAnswer)(nil),                // 7: pb.InputAnswer
+	(*SourceFile)(nil),                 // 8: pb.SourceFile
+	(*SubmitRequest)(nil),              // 9: pb.SubmitRequest
+	(*RunRequest)(nil),                 // 10: pb.RunRequest
+	(*SubmitResponse)(nil),             // 11: pb.SubmitResponse
+	(*JudgeUpdate)(nil),                // 12: pb.JudgeUpdate
+	(*UpdatesRequest)(nil),             // 13: pb.UpdatesRequest
+	(*JudgeClientRequest)(nil),         // 14: pb.JudgeClientRequest
+	(*JudgeClientResponse)(nil),        // 15: pb.JudgeClientResponse
+	(*Input)(nil),                      // 16: pb.Input
+	(*Resize)(nil),                     // 17: pb.Resize
+	(*ShellInput)(nil),                 // 18: pb.ShellInput
+	(*ShellAttach)(nil),                // 19: pb.ShellAttach
+	(*ShellShare)(nil),                 // 20: pb.ShellShare
+	(*ShellTransfer)(nil),              // 21: pb.ShellTransfer
+	(*ShellOutput)(nil),                // 22: pb.ShellOutput
+	(*ShellExit)(nil),                  // 23: pb.ShellExit
+	(*Checker)(nil),                    // 24: pb.Checker
+	(*Problem)(nil),                    // 25: pb.Problem
+	(*GetProblemRequest)(nil),          // 26: pb.GetProblemRequest
+	(*ListProblemsRequest)(nil),        // 27: pb.ListProblemsRequest
+	(*ListProblemsResponse)(nil),       // 28: pb.ListProblemsResponse
+	(*ImportProblemRequest)(nil),       // 29: pb.ImportProblemRequest
+	(*Program)(nil),                    // 30: pb.Program
+	(*BuildProblemRequest)(nil),        // 31: pb.BuildProblemRequest
+	(*BuildProblemResponse)(nil),       // 32: pb.BuildProblemResponse
+	(*FetchBlobRequest)(nil),           // 33: pb.FetchBlobRequest
+	(*BlobChunk)(nil),                  // 34: pb.BlobChunk
+	(*User)(nil),                       // 35: pb.User
+	(*GetUserRequest)(nil),             // 36: pb.GetUserRequest
+	(*VerifyUserRequest)(nil),          // 37: pb.VerifyUserRequest
+	(*ShellSession)(nil),               // 38: pb.ShellSession
+	(*ListShellSessionsRequest)(nil),   // 39: pb.ListShellSessionsRequest
+	(*ListShellSessionsResponse)(nil),  // 40: pb.ListShellSessionsResponse
+	(*GetShellSessionRequest)(nil),     // 41: pb.GetShellSessionRequest
+	(*ShellFile)(nil),                  // 42: pb.ShellFile
+	(*ListShellFilesRequest)(nil),      // 43: pb.ListShellFilesRequest
+	(*ListShellFilesResponse)(nil),     // 44: pb.ListShellFilesResponse
+	(*DeleteShellFileRequest)(nil),     // 45: pb.DeleteShellFileRequest
+	(*GetShellArchiveRequest)(nil),     // 46: pb.GetShellArchiveRequest
+	(*ShellWorkspace)(nil),             // 47: pb.ShellWorkspace
+	(*GetShellWorkspaceRequest)(nil),   // 48: pb.GetShellWorkspaceRequest
+	(*ResetShellWorkspaceRequest)(nil), // 49: pb.ResetShellWorkspaceRequest
+	(*ShellProfile)(nil),               // 50: pb.ShellProfile
+	(*ListShellProfilesRequest)(nil),   // 51: pb.ListShellProfilesRequest
+	(*ListShellProfilesResponse)(nil),  // 52: pb.ListShellProfilesResponse
+	(*LiveShell)(nil),                  // 53: pb.LiveShell
+	(*ListLiveShellsRequest)(nil),      // 54: pb.ListLiveShellsRequest
+	(*ListLiveShellsResponse)(nil),     // 55: pb.ListLiveShellsResponse
+	(*KillShellRequest)(nil),           // 56: pb.KillShellRequest
+	(*ShellEvent)(nil),                 // 57: pb.ShellEvent
+	(*ShellSessionChunk)(nil),          // 58: pb.ShellSessionChunk
+	(*timestamppb.Timestamp)(nil),      // 59: google.protobuf.Timestamp
 }
 var file_demo_backend_proto_depIdxs = []int32{
-	2,  // 0: pb.SubmissionResponse.submissions:type_name -> pb.Submission
-	3,  // 1: pb.Submission.language:type_name -> pb.Language
-	15, // 2: pb.Submission.date:type_name -> google.protobuf.Timestamp
-	4,  // 3: pb.Submission.results:type_name -> pb.Result
-	3,  // 4: pb.SubmitRequest.language:type_name -> pb.Language
-	5,  // 5: pb.SubmitRequest.inputAnswer:type_name -> pb.InputAnswer
-	15, // 6: pb.JudgeUpdate.date:type_name -> google.protobuf.Timestamp
-	3,  // 7: pb.JudgeUpdate.language:type_name -> pb.Language
-	4,  // 8: pb.JudgeUpdate.results:type_name -> pb.Result
-	3,  // 9: pb.JudgeClientRequest.language:type_name -> pb.Language
-	5,  // 10: pb.JudgeClientRequest.inputAnswer:type_name -> pb.InputAnswer
-	15, // 11: pb.JudgeClientResponse.date:type_name -> google.protobuf.Timestamp
-	3,  // 12: pb.JudgeClientResponse.language:type_name -> pb.Language
-	4,  // 13: pb.JudgeClientResponse.results:type_name -> pb.Result
-	11, // 14: pb.ShellInput.input:type_name -> pb.Input
-	12, // 15: pb.ShellInput.resize:type_name -> pb.Resize
-	0,  // 16: pb.DemoBackend.Submission:input_type -> pb.SubmissionRequest
-	6,  // 17: pb.DemoBackend.Submit:input_type -> pb.SubmitRequest
-	16, // 18: pb.DemoBackend.Updates:input_type -> google.protobuf.Empty
-	10, // 19: pb.DemoBackend.Judge:input_type -> pb.JudgeClientResponse
-	13, // 20: pb.DemoBackend.Shell:input_type -> pb.ShellInput
-	1,  // 21: pb.DemoBackend.Submission:output_type -> pb.SubmissionResponse
-	7,  // 22: pb.DemoBackend.Submit:output_type -> pb.SubmitResponse
-	8,  // 23: pb.DemoBackend.Updates:output_type -> pb.JudgeUpdate
-	9,  // 24: pb.DemoBackend.Judge:output_type -> pb.JudgeClientRequest
-	14, // 25: pb.DemoBackend.Shell:output_type -> pb.ShellOutput
-	21, // [21:26] is the sub-list for method output_type
-	16, // [16:21] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	4,  // 0: pb.SubmissionResponse.submissions:type_name -> pb.Submission
+	5,  // 1: pb.Submission.language:type_name -> pb.Language
+	59, // 2: pb.Submission.date:type_name -> google.protobuf.Timestamp
+	6,  // 3: pb.Submission.results:type_name -> pb.Result
+	8,  // 4: pb.Submission.files:type_name -> pb.SourceFile
+	5,  // 5: pb.SubmitRequest.language:type_name -> pb.Language
+	7,  // 6: pb.SubmitRequest.inputAnswer:type_name -> pb.InputAnswer
+	8,  // 7: pb.SubmitRequest.files:type_name -> pb.SourceFile
+	0,  // 8: pb.SubmitRequest.priority:type_name -> pb.Priority
+	9,  // 9: pb.RunRequest.submit:type_name -> pb.SubmitRequest
+	59, // 10: pb.JudgeUpdate.date:type_name -> google.protobuf.Timestamp
+	5,  // 11: pb.JudgeUpdate.language:type_name -> pb.Language
+	6,  // 12: pb.JudgeUpdate.results:type_name -> pb.Result
+	5,  // 13: pb.JudgeClientRequest.language:type_name -> pb.Language
+	7,  // 14: pb.JudgeClientRequest.inputAnswer:type_name -> pb.InputAnswer
+	8,  // 15: pb.JudgeClientRequest.files:type_name -> pb.SourceFile
+	24, // 16: pb.JudgeClientRequest.checker:type_name -> pb.Checker
+	31, // 17: pb.JudgeClientRequest.buildProblem:type_name -> pb.BuildProblemRequest
+	59, // 18: pb.JudgeClientResponse.date:type_name -> google.protobuf.Timestamp
+	5,  // 19: pb.JudgeClientResponse.language:type_name -> pb.Language
+	6,  // 20: pb.JudgeClientResponse.results:type_name -> pb.Result
+	7,  // 21: pb.JudgeClientResponse.inputAnswer:type_name -> pb.InputAnswer
+	16, // 22: pb.ShellInput.input:type_name -> pb.Input
+	17, // 23: pb.ShellInput.resize:type_name -> pb.Resize
+	19, // 24: pb.ShellInput.attach:type_name -> pb.ShellAttach
+	20, // 25: pb.ShellInput.share:type_name -> pb.ShellShare
+	21, // 26: pb.ShellInput.transfer:type_name -> pb.ShellTransfer
+	23, // 27: pb.ShellOutput.exit:type_name -> pb.ShellExit
+	21, // 28: pb.ShellOutput.transfer:type_name -> pb.ShellTransfer
+	5,  // 29: pb.Checker.language:type_name -> pb.Language
+	8,  // 30: pb.Checker.files:type_name -> pb.SourceFile
+	24, // 31: pb.Problem.checker:type_name -> pb.Checker
+	7,  // 32: pb.Problem.testCases:type_name -> pb.InputAnswer
+	59, // 33: pb.Problem.date:type_name -> google.protobuf.Timestamp
+	25, // 34: pb.ListProblemsResponse.problems:type_name -> pb.Problem
+	5,  // 35: pb.Program.language:type_name -> pb.Language
+	8,  // 36: pb.Program.files:type_name -> pb.SourceFile
+	30, // 37: pb.BuildProblemRequest.generators:type_name -> pb.Program
+	30, // 38: pb.BuildProblemRequest.validator:type_name -> pb.Program
+	30, // 39: pb.BuildProblemRequest.solution:type_name -> pb.Program
+	59, // 40: pb.User.date:type_name -> google.protobuf.Timestamp
+	59, // 41: pb.ShellSession.start:type_name -> google.protobuf.Timestamp
+	59, // 42: pb.ShellSession.end:type_name -> google.protobuf.Timestamp
+	38, // 43: pb.ListShellSessionsResponse.sessions:type_name -> pb.ShellSession
+	42, // 44: pb.ListShellFilesResponse.files:type_name -> pb.ShellFile
+	59, // 45: pb.ShellWorkspace.updated:type_name -> google.protobuf.Timestamp
+	50, // 46: pb.ListShellProfilesResponse.profiles:type_name -> pb.ShellProfile
+	59, // 47: pb.LiveShell.start:type_name -> google.protobuf.Timestamp
+	59, // 48: pb.LiveShell.lastInput:type_name -> google.protobuf.Timestamp
+	53, // 49: pb.ListLiveShellsResponse.shells:type_name -> pb.LiveShell
+	38, // 50: pb.ShellSessionChunk.session:type_name -> pb.ShellSession
+	57, // 51: pb.ShellSessionChunk.events:type_name -> pb.ShellEvent
+	1,  // 52: pb.DemoBackend.Submission:input_type -> pb.SubmissionRequest
+	3,  // 53: pb.DemoBackend.GetSubmission:input_type -> pb.GetSubmissionRequest
+	9,  // 54: pb.DemoBackend.Submit:input_type -> pb.SubmitRequest
+	13, // 55: pb.DemoBackend.Updates:input_type -> pb.UpdatesRequest
+	10, // 56: pb.DemoBackend.Run:input_type -> pb.RunRequest
+	15, // 57: pb.DemoBackend.Judge:input_type -> pb.JudgeClientResponse
+	18, // 58: pb.DemoBackend.Shell:input_type -> pb.ShellInput
+	25, // 59: pb.DemoBackend.CreateProblem:input_type -> pb.Problem
+	26, // 60: pb.DemoBackend.GetProblem:input_type -> pb.GetProblemRequest
+	27, // 61: pb.DemoBackend.ListProblems:input_type -> pb.ListProblemsRequest
+	25, // 62: pb.DemoBackend.UpdateProblem:input_type -> pb.Problem
+	33, // 63: pb.DemoBackend.FetchBlob:input_type -> pb.FetchBlobRequest
+	29, // 64: pb.DemoBackend.ImportProblem:input_type -> pb.ImportProblemRequest
+	31, // 65: pb.DemoBackend.BuildProblem:input_type -> pb.BuildProblemRequest
+	35, // 66: pb.DemoBackend.CreateUser:input_type -> pb.User
+	36, // 67: pb.DemoBackend.GetUser:input_type -> pb.GetUserRequest
+	37, // 68: pb.DemoBackend.VerifyUser:input_type -> pb.VerifyUserRequest
+	39, // 69: pb.DemoBackend.ListShellSessions:input_type -> pb.ListShellSessionsRequest
+	41, // 70: pb.DemoBackend.GetShellSession:input_type -> pb.GetShellSessionRequest
+	42, // 71: pb.DemoBackend.UploadShellFile:input_type -> pb.ShellFile
+	43, // 72: pb.DemoBackend.ListShellFiles:input_type -> pb.ListShellFilesRequest
+	45, // 73: pb.DemoBackend.DeleteShellFile:input_type -> pb.DeleteShellFileRequest
+	46, // 74: pb.DemoBackend.GetShellArchive:input_type -> pb.GetShellArchiveRequest
+	48, // 75: pb.DemoBackend.GetShellWorkspace:input_type -> pb.GetShellWorkspaceRequest
+	49, // 76: pb.DemoBackend.ResetShellWorkspace:input_type -> pb.ResetShellWorkspaceRequest
+	51, // 77: pb.DemoBackend.ListShellProfiles:input_type -> pb.ListShellProfilesRequest
+	54, // 78: pb.DemoBackend.ListLiveShells:input_type -> pb.ListLiveShellsRequest
+	56, // 79: pb.DemoBackend.KillShell:input_type -> pb.KillShellRequest
+	2,  // 80: pb.DemoBackend.Submission:output_type -> pb.SubmissionResponse
+	4,  // 81: pb.DemoBackend.GetSubmission:output_type -> pb.Submission
+	11, // 82: pb.DemoBackend.Submit:output_type -> pb.SubmitResponse
+	12, // 83: pb.DemoBackend.Updates:output_type -> pb.JudgeUpdate
+	12, // 84: pb.DemoBackend.Run:output_type -> pb.JudgeUpdate
+	14, // 85: pb.DemoBackend.Judge:output_type -> pb.JudgeClientRequest
+	22, // 86: pb.DemoBackend.Shell:output_type -> pb.ShellOutput
+	25, // 87: pb.DemoBackend.CreateProblem:output_type -> pb.Problem
+	25, // 88: pb.DemoBackend.GetProblem:output_type -> pb.Problem
+	28, // 89: pb.DemoBackend.ListProblems:output_type -> pb.ListProblemsResponse
+	25, // 90: pb.DemoBackend.UpdateProblem:output_type -> pb.Problem
+	34, // 91: pb.DemoBackend.FetchBlob:output_type -> pb.BlobChunk
+	25, // 92: pb.DemoBackend.ImportProblem:output_type -> pb.Problem
+	32, // 93: pb.DemoBackend.BuildProblem:output_type -> pb.BuildProblemResponse
+	35, // 94: pb.DemoBackend.CreateUser:output_type -> pb.User
+	35, // 95: pb.DemoBackend.GetUser:output_type -> pb.User
+	35, // 96: pb.DemoBackend.VerifyUser:output_type -> pb.User
+	40, // 97: pb.DemoBackend.ListShellSessions:output_type -> pb.ListShellSessionsResponse
+	58, // 98: pb.DemoBackend.GetShellSession:output_type -> pb.ShellSessionChunk
+	44, // 99: pb.DemoBackend.UploadShellFile:output_type -> pb.ListShellFilesResponse
+	44, // 100: pb.DemoBackend.ListShellFiles:output_type -> pb.ListShellFilesResponse
+	44, // 101: pb.DemoBackend.DeleteShellFile:output_type -> pb.ListShellFilesResponse
+	34, // 102: pb.DemoBackend.GetShellArchive:output_type -> pb.BlobChunk
+	47, // 103: pb.DemoBackend.GetShellWorkspace:output_type -> pb.ShellWorkspace
+	47, // 104: pb.DemoBackend.ResetShellWorkspace:output_type -> pb.ShellWorkspace
+	52, // 105: pb.DemoBackend.ListShellProfiles:output_type -> pb.ListShellProfilesResponse
+	55, // 106: pb.DemoBackend.ListLiveShells:output_type -> pb.ListLiveShellsResponse
+	53, // 107: pb.DemoBackend.KillShell:output_type -> pb.LiveShell
+	80, // [80:108] is the sub-list for method output_type
+	52, // [52:80] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_demo_backend_proto_init() }
@@ -2520,22 +9259,26 @@ func file_demo_backend_proto_init() {
 	if File_demo_backend_proto != nil {
 		return
 	}
-	file_demo_backend_proto_msgTypes[13].OneofWrappers = []any{
+	file_demo_backend_proto_msgTypes[17].OneofWrappers = []any{
 		(*shellInput_Input)(nil),
 		(*shellInput_Resize)(nil),
+		(*shellInput_Attach)(nil),
+		(*shellInput_Share)(nil),
+		(*shellInput_Transfer)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_demo_backend_proto_rawDesc), len(file_demo_backend_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   15,
+			NumEnums:      1,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_demo_backend_proto_goTypes,
 		DependencyIndexes: file_demo_backend_proto_depIdxs,
+		EnumInfos:         file_demo_backend_proto_enumTypes,
 		MessageInfos:      file_demo_backend_proto_msgTypes,
 	}.Build()
 	File_demo_backend_proto = out.File
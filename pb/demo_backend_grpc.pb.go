@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,11 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DemoBackend_Submission_FullMethodName = "/pb.DemoBackend/Submission"
-	DemoBackend_Submit_FullMethodName     = "/pb.DemoBackend/Submit"
-	DemoBackend_Updates_FullMethodName    = "/pb.DemoBackend/Updates"
-	DemoBackend_Judge_FullMethodName      = "/pb.DemoBackend/Judge"
-	DemoBackend_Shell_FullMethodName      = "/pb.DemoBackend/Shell"
+	DemoBackend_Submission_FullMethodName          = "/pb.DemoBackend/Submission"
+	DemoBackend_GetSubmission_FullMethodName       = "/pb.DemoBackend/GetSubmission"
+	DemoBackend_Submit_FullMethodName              = "/pb.DemoBackend/Submit"
+	DemoBackend_Updates_FullMethodName             = "/pb.DemoBackend/Updates"
+	DemoBackend_Run_FullMethodName                 = "/pb.DemoBackend/Run"
+	DemoBackend_Judge_FullMethodName               = "/pb.DemoBackend/Judge"
+	DemoBackend_Shell_FullMethodName               = "/pb.DemoBackend/Shell"
+	DemoBackend_CreateProblem_FullMethodName       = "/pb.DemoBackend/CreateProblem"
+	DemoBackend_GetProblem_FullMethodName          = "/pb.DemoBackend/GetProblem"
+	DemoBackend_ListProblems_FullMethodName        = "/pb.DemoBackend/ListProblems"
+	DemoBackend_UpdateProblem_FullMethodName       = "/pb.DemoBackend/UpdateProblem"
+	DemoBackend_FetchBlob_FullMethodName           = "/pb.DemoBackend/FetchBlob"
+	DemoBackend_ImportProblem_FullMethodName       = "/pb.DemoBackend/ImportProblem"
+	DemoBackend_BuildProblem_FullMethodName        = "/pb.DemoBackend/BuildProblem"
+	DemoBackend_CreateUser_FullMethodName          = "/pb.DemoBackend/CreateUser"
+	DemoBackend_GetUser_FullMethodName             = "/pb.DemoBackend/GetUser"
+	DemoBackend_VerifyUser_FullMethodName          = "/pb.DemoBackend/VerifyUser"
+	DemoBackend_ListShellSessions_FullMethodName   = "/pb.DemoBackend/ListShellSessions"
+	DemoBackend_GetShellSession_FullMethodName     = "/pb.DemoBackend/GetShellSession"
+	DemoBackend_UploadShellFile_FullMethodName     = "/pb.DemoBackend/UploadShellFile"
+	DemoBackend_ListShellFiles_FullMethodName      = "/pb.DemoBackend/ListShellFiles"
+	DemoBackend_DeleteShellFile_FullMethodName     = "/pb.DemoBackend/DeleteShellFile"
+	DemoBackend_GetShellArchive_FullMethodName     = "/pb.DemoBackend/GetShellArchive"
+	DemoBackend_GetShellWorkspace_FullMethodName   = "/pb.DemoBackend/GetShellWorkspace"
+	DemoBackend_ResetShellWorkspace_FullMethodName = "/pb.DemoBackend/ResetShellWorkspace"
+	DemoBackend_ListShellProfiles_FullMethodName   = "/pb.DemoBackend/ListShellProfiles"
+	DemoBackend_ListLiveShells_FullMethodName      = "/pb.DemoBackend/ListLiveShells"
+	DemoBackend_KillShell_FullMethodName           = "/pb.DemoBackend/KillShell"
 )
 
 // DemoBackendClient is the client API for DemoBackend service.
@@ -32,10 +54,33 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DemoBackendClient interface {
 	Submission(ctx context.Context, in *SubmissionRequest, opts ...grpc.CallOption) (*SubmissionResponse, error)
+	GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*Submission, error)
 	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
-	Updates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JudgeUpdate], error)
+	Updates(ctx context.Context, in *UpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JudgeUpdate], error)
+	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*JudgeUpdate, error)
 	Judge(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[JudgeClientResponse, JudgeClientRequest], error)
 	Shell(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ShellInput, ShellOutput], error)
+	CreateProblem(ctx context.Context, in *Problem, opts ...grpc.CallOption) (*Problem, error)
+	GetProblem(ctx context.Context, in *GetProblemRequest, opts ...grpc.CallOption) (*Problem, error)
+	ListProblems(ctx context.Context, in *ListProblemsRequest, opts ...grpc.CallOption) (*ListProblemsResponse, error)
+	UpdateProblem(ctx context.Context, in *Problem, opts ...grpc.CallOption) (*Problem, error)
+	FetchBlob(ctx context.Context, in *FetchBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlobChunk], error)
+	ImportProblem(ctx context.Context, in *ImportProblemRequest, opts ...grpc.CallOption) (*Problem, error)
+	BuildProblem(ctx context.Context, in *BuildProblemRequest, opts ...grpc.CallOption) (*BuildProblemResponse, error)
+	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	VerifyUser(ctx context.Context, in *VerifyUserRequest, opts ...grpc.CallOption) (*User, error)
+	ListShellSessions(ctx context.Context, in *ListShellSessionsRequest, opts ...grpc.CallOption) (*ListShellSessionsResponse, error)
+	GetShellSession(ctx context.Context, in *GetShellSessionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ShellSessionChunk], error)
+	UploadShellFile(ctx context.Context, in *ShellFile, opts ...grpc.CallOption) (*ListShellFilesResponse, error)
+	ListShellFiles(ctx context.Context, in *ListShellFilesRequest, opts ...grpc.CallOption) (*ListShellFilesResponse, error)
+	DeleteShellFile(ctx context.Context, in *DeleteShellFileRequest, opts ...grpc.CallOption) (*ListShellFilesResponse, error)
+	GetShellArchive(ctx context.Context, in *GetShellArchiveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlobChunk], error)
+	GetShellWorkspace(ctx context.Context, in *GetShellWorkspaceRequest, opts ...grpc.CallOption) (*ShellWorkspace, error)
+	ResetShellWorkspace(ctx context.Context, in *ResetShellWorkspaceRequest, opts ...grpc.CallOption) (*ShellWorkspace, error)
+	ListShellProfiles(ctx context.Context, in *ListShellProfilesRequest, opts ...grpc.CallOption) (*ListShellProfilesResponse, error)
+	ListLiveShells(ctx context.Context, in *ListLiveShellsRequest, opts ...grpc.CallOption) (*ListLiveShellsResponse, error)
+	KillShell(ctx context.Context, in *KillShellRequest, opts ...grpc.CallOption) (*LiveShell, error)
 }
 
 type demoBackendClient struct {
@@ -56,6 +101,16 @@ func (c *demoBackendClient) Submission(ctx context.Context, in *SubmissionReques
 	return out, nil
 }
 
+func (c *demoBackendClient) GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*Submission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Submission)
+	err := c.cc.Invoke(ctx, DemoBackend_GetSubmission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *demoBackendClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubmitResponse)
@@ -66,13 +121,13 @@ func (c *demoBackendClient) Submit(ctx context.Context, in *SubmitRequest, opts
 	return out, nil
 }
 
-func (c *demoBackendClient) Updates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JudgeUpdate], error) {
+func (c *demoBackendClient) Updates(ctx context.Context, in *UpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JudgeUpdate], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &DemoBackend_ServiceDesc.Streams[0], DemoBackend_Updates_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[emptypb.Empty, JudgeUpdate]{ClientStream: stream}
+	x := &grpc.GenericClientStream[UpdatesRequest, JudgeUpdate]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -85,6 +140,16 @@ func (c *demoBackendClient) Updates(ctx context.Context, in *emptypb.Empty, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DemoBackend_UpdatesClient = grpc.ServerStreamingClient[JudgeUpdate]
 
+func (c *demoBackendClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*JudgeUpdate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JudgeUpdate)
+	err := c.cc.Invoke(ctx, DemoBackend_Run_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *demoBackendClient) Judge(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[JudgeClientResponse, JudgeClientRequest], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &DemoBackend_ServiceDesc.Streams[1], DemoBackend_Judge_FullMethodName, cOpts...)
@@ -111,15 +176,275 @@ func (c *demoBackendClient) Shell(ctx context.Context, opts ...grpc.CallOption)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DemoBackend_ShellClient = grpc.BidiStreamingClient[ShellInput, ShellOutput]
 
+func (c *demoBackendClient) CreateProblem(ctx context.Context, in *Problem, opts ...grpc.CallOption) (*Problem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Problem)
+	err := c.cc.Invoke(ctx, DemoBackend_CreateProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) GetProblem(ctx context.Context, in *GetProblemRequest, opts ...grpc.CallOption) (*Problem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Problem)
+	err := c.cc.Invoke(ctx, DemoBackend_GetProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) ListProblems(ctx context.Context, in *ListProblemsRequest, opts ...grpc.CallOption) (*ListProblemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProblemsResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_ListProblems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) UpdateProblem(ctx context.Context, in *Problem, opts ...grpc.CallOption) (*Problem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Problem)
+	err := c.cc.Invoke(ctx, DemoBackend_UpdateProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) FetchBlob(ctx context.Context, in *FetchBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlobChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DemoBackend_ServiceDesc.Streams[3], DemoBackend_FetchBlob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchBlobRequest, BlobChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DemoBackend_FetchBlobClient = grpc.ServerStreamingClient[BlobChunk]
+
+func (c *demoBackendClient) ImportProblem(ctx context.Context, in *ImportProblemRequest, opts ...grpc.CallOption) (*Problem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Problem)
+	err := c.cc.Invoke(ctx, DemoBackend_ImportProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) BuildProblem(ctx context.Context, in *BuildProblemRequest, opts ...grpc.CallOption) (*BuildProblemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildProblemResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_BuildProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, DemoBackend_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, DemoBackend_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) VerifyUser(ctx context.Context, in *VerifyUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, DemoBackend_VerifyUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) ListShellSessions(ctx context.Context, in *ListShellSessionsRequest, opts ...grpc.CallOption) (*ListShellSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShellSessionsResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_ListShellSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) GetShellSession(ctx context.Context, in *GetShellSessionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ShellSessionChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DemoBackend_ServiceDesc.Streams[4], DemoBackend_GetShellSession_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetShellSessionRequest, ShellSessionChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DemoBackend_GetShellSessionClient = grpc.ServerStreamingClient[ShellSessionChunk]
+
+func (c *demoBackendClient) UploadShellFile(ctx context.Context, in *ShellFile, opts ...grpc.CallOption) (*ListShellFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShellFilesResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_UploadShellFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) ListShellFiles(ctx context.Context, in *ListShellFilesRequest, opts ...grpc.CallOption) (*ListShellFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShellFilesResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_ListShellFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) DeleteShellFile(ctx context.Context, in *DeleteShellFileRequest, opts ...grpc.CallOption) (*ListShellFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShellFilesResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_DeleteShellFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) GetShellArchive(ctx context.Context, in *GetShellArchiveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlobChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DemoBackend_ServiceDesc.Streams[5], DemoBackend_GetShellArchive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetShellArchiveRequest, BlobChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DemoBackend_GetShellArchiveClient = grpc.ServerStreamingClient[BlobChunk]
+
+func (c *demoBackendClient) GetShellWorkspace(ctx context.Context, in *GetShellWorkspaceRequest, opts ...grpc.CallOption) (*ShellWorkspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShellWorkspace)
+	err := c.cc.Invoke(ctx, DemoBackend_GetShellWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) ResetShellWorkspace(ctx context.Context, in *ResetShellWorkspaceRequest, opts ...grpc.CallOption) (*ShellWorkspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShellWorkspace)
+	err := c.cc.Invoke(ctx, DemoBackend_ResetShellWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) ListShellProfiles(ctx context.Context, in *ListShellProfilesRequest, opts ...grpc.CallOption) (*ListShellProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShellProfilesResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_ListShellProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) ListLiveShells(ctx context.Context, in *ListLiveShellsRequest, opts ...grpc.CallOption) (*ListLiveShellsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLiveShellsResponse)
+	err := c.cc.Invoke(ctx, DemoBackend_ListLiveShells_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoBackendClient) KillShell(ctx context.Context, in *KillShellRequest, opts ...grpc.CallOption) (*LiveShell, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveShell)
+	err := c.cc.Invoke(ctx, DemoBackend_KillShell_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DemoBackendServer is the server API for DemoBackend service.
 // All implementations must embed UnimplementedDemoBackendServer
 // for forward compatibility.
 type DemoBackendServer interface {
 	Submission(context.Context, *SubmissionRequest) (*SubmissionResponse, error)
+	GetSubmission(context.Context, *GetSubmissionRequest) (*Submission, error)
 	Submit(context.Context, *SubmitRequest) (*SubmitResponse, error)
-	Updates(*emptypb.Empty, grpc.ServerStreamingServer[JudgeUpdate]) error
+	Updates(*UpdatesRequest, grpc.ServerStreamingServer[JudgeUpdate]) error
+	Run(context.Context, *RunRequest) (*JudgeUpdate, error)
 	Judge(grpc.BidiStreamingServer[JudgeClientResponse, JudgeClientRequest]) error
 	Shell(grpc.BidiStreamingServer[ShellInput, ShellOutput]) error
+	CreateProblem(context.Context, *Problem) (*Problem, error)
+	GetProblem(context.Context, *GetProblemRequest) (*Problem, error)
+	ListProblems(context.Context, *ListProblemsRequest) (*ListProblemsResponse, error)
+	UpdateProblem(context.Context, *Problem) (*Problem, error)
+	FetchBlob(*FetchBlobRequest, grpc.ServerStreamingServer[BlobChunk]) error
+	ImportProblem(context.Context, *ImportProblemRequest) (*Problem, error)
+	BuildProblem(context.Context, *BuildProblemRequest) (*BuildProblemResponse, error)
+	CreateUser(context.Context, *User) (*User, error)
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	VerifyUser(context.Context, *VerifyUserRequest) (*User, error)
+	ListShellSessions(context.Context, *ListShellSessionsRequest) (*ListShellSessionsResponse, error)
+	GetShellSession(*GetShellSessionRequest, grpc.ServerStreamingServer[ShellSessionChunk]) error
+	UploadShellFile(context.Context, *ShellFile) (*ListShellFilesResponse, error)
+	ListShellFiles(context.Context, *ListShellFilesRequest) (*ListShellFilesResponse, error)
+	DeleteShellFile(context.Context, *DeleteShellFileRequest) (*ListShellFilesResponse, error)
+	GetShellArchive(*GetShellArchiveRequest, grpc.ServerStreamingServer[BlobChunk]) error
+	GetShellWorkspace(context.Context, *GetShellWorkspaceRequest) (*ShellWorkspace, error)
+	ResetShellWorkspace(context.Context, *ResetShellWorkspaceRequest) (*ShellWorkspace, error)
+	ListShellProfiles(context.Context, *ListShellProfilesRequest) (*ListShellProfilesResponse, error)
+	ListLiveShells(context.Context, *ListLiveShellsRequest) (*ListLiveShellsResponse, error)
+	KillShell(context.Context, *KillShellRequest) (*LiveShell, error)
 	mustEmbedUnimplementedDemoBackendServer()
 }
 
@@ -133,18 +458,87 @@ type UnimplementedDemoBackendServer struct{}
 func (UnimplementedDemoBackendServer) Submission(context.Context, *SubmissionRequest) (*SubmissionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Submission not implemented")
 }
+func (UnimplementedDemoBackendServer) GetSubmission(context.Context, *GetSubmissionRequest) (*Submission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmission not implemented")
+}
 func (UnimplementedDemoBackendServer) Submit(context.Context, *SubmitRequest) (*SubmitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
 }
-func (UnimplementedDemoBackendServer) Updates(*emptypb.Empty, grpc.ServerStreamingServer[JudgeUpdate]) error {
+func (UnimplementedDemoBackendServer) Updates(*UpdatesRequest, grpc.ServerStreamingServer[JudgeUpdate]) error {
 	return status.Errorf(codes.Unimplemented, "method Updates not implemented")
 }
+func (UnimplementedDemoBackendServer) Run(context.Context, *RunRequest) (*JudgeUpdate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
 func (UnimplementedDemoBackendServer) Judge(grpc.BidiStreamingServer[JudgeClientResponse, JudgeClientRequest]) error {
 	return status.Errorf(codes.Unimplemented, "method Judge not implemented")
 }
 func (UnimplementedDemoBackendServer) Shell(grpc.BidiStreamingServer[ShellInput, ShellOutput]) error {
 	return status.Errorf(codes.Unimplemented, "method Shell not implemented")
 }
+func (UnimplementedDemoBackendServer) CreateProblem(context.Context, *Problem) (*Problem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProblem not implemented")
+}
+func (UnimplementedDemoBackendServer) GetProblem(context.Context, *GetProblemRequest) (*Problem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProblem not implemented")
+}
+func (UnimplementedDemoBackendServer) ListProblems(context.Context, *ListProblemsRequest) (*ListProblemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProblems not implemented")
+}
+func (UnimplementedDemoBackendServer) UpdateProblem(context.Context, *Problem) (*Problem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProblem not implemented")
+}
+func (UnimplementedDemoBackendServer) FetchBlob(*FetchBlobRequest, grpc.ServerStreamingServer[BlobChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchBlob not implemented")
+}
+func (UnimplementedDemoBackendServer) ImportProblem(context.Context, *ImportProblemRequest) (*Problem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProblem not implemented")
+}
+func (UnimplementedDemoBackendServer) BuildProblem(context.Context, *BuildProblemRequest) (*BuildProblemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildProblem not implemented")
+}
+func (UnimplementedDemoBackendServer) CreateUser(context.Context, *User) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedDemoBackendServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedDemoBackendServer) VerifyUser(context.Context, *VerifyUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyUser not implemented")
+}
+func (UnimplementedDemoBackendServer) ListShellSessions(context.Context, *ListShellSessionsRequest) (*ListShellSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShellSessions not implemented")
+}
+func (UnimplementedDemoBackendServer) GetShellSession(*GetShellSessionRequest, grpc.ServerStreamingServer[ShellSessionChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetShellSession not implemented")
+}
+func (UnimplementedDemoBackendServer) UploadShellFile(context.Context, *ShellFile) (*ListShellFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadShellFile not implemented")
+}
+func (UnimplementedDemoBackendServer) ListShellFiles(context.Context, *ListShellFilesRequest) (*ListShellFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShellFiles not implemented")
+}
+func (UnimplementedDemoBackendServer) DeleteShellFile(context.Context, *DeleteShellFileRequest) (*ListShellFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShellFile not implemented")
+}
+func (UnimplementedDemoBackendServer) GetShellArchive(*GetShellArchiveRequest, grpc.ServerStreamingServer[BlobChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetShellArchive not implemented")
+}
+func (UnimplementedDemoBackendServer) GetShellWorkspace(context.Context, *GetShellWorkspaceRequest) (*ShellWorkspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShellWorkspace not implemented")
+}
+func (UnimplementedDemoBackendServer) ResetShellWorkspace(context.Context, *ResetShellWorkspaceRequest) (*ShellWorkspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetShellWorkspace not implemented")
+}
+func (UnimplementedDemoBackendServer) ListShellProfiles(context.Context, *ListShellProfilesRequest) (*ListShellProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShellProfiles not implemented")
+}
+func (UnimplementedDemoBackendServer) ListLiveShells(context.Context, *ListLiveShellsRequest) (*ListLiveShellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLiveShells not implemented")
+}
+func (UnimplementedDemoBackendServer) KillShell(context.Context, *KillShellRequest) (*LiveShell, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillShell not implemented")
+}
 func (UnimplementedDemoBackendServer) mustEmbedUnimplementedDemoBackendServer() {}
 func (UnimplementedDemoBackendServer) testEmbeddedByValue()                     {}
 
@@ -184,6 +578,24 @@ func _DemoBackend_Submission_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DemoBackend_GetSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).GetSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_GetSubmission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).GetSubmission(ctx, req.(*GetSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DemoBackend_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitRequest)
 	if err := dec(in); err != nil {
@@ -203,16 +615,34 @@ func _DemoBackend_Submit_Handler(srv interface{}, ctx context.Context, dec func(
 }
 
 func _DemoBackend_Updates_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+	m := new(UpdatesRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(DemoBackendServer).Updates(m, &grpc.GenericServerStream[emptypb.Empty, JudgeUpdate]{ServerStream: stream})
+	return srv.(DemoBackendServer).Updates(m, &grpc.GenericServerStream[UpdatesRequest, JudgeUpdate]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DemoBackend_UpdatesServer = grpc.ServerStreamingServer[JudgeUpdate]
 
+func _DemoBackend_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).Run(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_Run_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).Run(ctx, req.(*RunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DemoBackend_Judge_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(DemoBackendServer).Judge(&grpc.GenericServerStream[JudgeClientResponse, JudgeClientRequest]{ServerStream: stream})
 }
@@ -227,28 +657,465 @@ func _DemoBackend_Shell_Handler(srv interface{}, stream grpc.ServerStream) error
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DemoBackend_ShellServer = grpc.BidiStreamingServer[ShellInput, ShellOutput]
 
-// DemoBackend_ServiceDesc is the grpc.ServiceDesc for DemoBackend service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var DemoBackend_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.DemoBackend",
-	HandlerType: (*DemoBackendServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Submission",
-			Handler:    _DemoBackend_Submission_Handler,
-		},
-		{
-			MethodName: "Submit",
-			Handler:    _DemoBackend_Submit_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Updates",
-			Handler:       _DemoBackend_Updates_Handler,
-			ServerStreams: true,
-		},
+func _DemoBackend_CreateProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Problem)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).CreateProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_CreateProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).CreateProblem(ctx, req.(*Problem))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_GetProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).GetProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_GetProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).GetProblem(ctx, req.(*GetProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_ListProblems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProblemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ListProblems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ListProblems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ListProblems(ctx, req.(*ListProblemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_UpdateProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Problem)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).UpdateProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_UpdateProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).UpdateProblem(ctx, req.(*Problem))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_FetchBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DemoBackendServer).FetchBlob(m, &grpc.GenericServerStream[FetchBlobRequest, BlobChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DemoBackend_FetchBlobServer = grpc.ServerStreamingServer[BlobChunk]
+
+func _DemoBackend_ImportProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ImportProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ImportProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ImportProblem(ctx, req.(*ImportProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_BuildProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).BuildProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_BuildProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).BuildProblem(ctx, req.(*BuildProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).CreateUser(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_VerifyUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).VerifyUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_VerifyUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).VerifyUser(ctx, req.(*VerifyUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_ListShellSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShellSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ListShellSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ListShellSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ListShellSessions(ctx, req.(*ListShellSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_GetShellSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetShellSessionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DemoBackendServer).GetShellSession(m, &grpc.GenericServerStream[GetShellSessionRequest, ShellSessionChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DemoBackend_GetShellSessionServer = grpc.ServerStreamingServer[ShellSessionChunk]
+
+func _DemoBackend_UploadShellFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShellFile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).UploadShellFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_UploadShellFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).UploadShellFile(ctx, req.(*ShellFile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_ListShellFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShellFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ListShellFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ListShellFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ListShellFiles(ctx, req.(*ListShellFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_DeleteShellFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShellFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).DeleteShellFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_DeleteShellFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).DeleteShellFile(ctx, req.(*DeleteShellFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_GetShellArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetShellArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DemoBackendServer).GetShellArchive(m, &grpc.GenericServerStream[GetShellArchiveRequest, BlobChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DemoBackend_GetShellArchiveServer = grpc.ServerStreamingServer[BlobChunk]
+
+func _DemoBackend_GetShellWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShellWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).GetShellWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_GetShellWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).GetShellWorkspace(ctx, req.(*GetShellWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_ResetShellWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetShellWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ResetShellWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ResetShellWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ResetShellWorkspace(ctx, req.(*ResetShellWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_ListShellProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShellProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ListShellProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ListShellProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ListShellProfiles(ctx, req.(*ListShellProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_ListLiveShells_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLiveShellsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).ListLiveShells(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_ListLiveShells_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).ListLiveShells(ctx, req.(*ListLiveShellsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoBackend_KillShell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillShellRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoBackendServer).KillShell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DemoBackend_KillShell_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoBackendServer).KillShell(ctx, req.(*KillShellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DemoBackend_ServiceDesc is the grpc.ServiceDesc for DemoBackend service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DemoBackend_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.DemoBackend",
+	HandlerType: (*DemoBackendServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Submission",
+			Handler:    _DemoBackend_Submission_Handler,
+		},
+		{
+			MethodName: "GetSubmission",
+			Handler:    _DemoBackend_GetSubmission_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _DemoBackend_Submit_Handler,
+		},
+		{
+			MethodName: "Run",
+			Handler:    _DemoBackend_Run_Handler,
+		},
+		{
+			MethodName: "CreateProblem",
+			Handler:    _DemoBackend_CreateProblem_Handler,
+		},
+		{
+			MethodName: "GetProblem",
+			Handler:    _DemoBackend_GetProblem_Handler,
+		},
+		{
+			MethodName: "ListProblems",
+			Handler:    _DemoBackend_ListProblems_Handler,
+		},
+		{
+			MethodName: "UpdateProblem",
+			Handler:    _DemoBackend_UpdateProblem_Handler,
+		},
+		{
+			MethodName: "ImportProblem",
+			Handler:    _DemoBackend_ImportProblem_Handler,
+		},
+		{
+			MethodName: "BuildProblem",
+			Handler:    _DemoBackend_BuildProblem_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _DemoBackend_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _DemoBackend_GetUser_Handler,
+		},
+		{
+			MethodName: "VerifyUser",
+			Handler:    _DemoBackend_VerifyUser_Handler,
+		},
+		{
+			MethodName: "ListShellSessions",
+			Handler:    _DemoBackend_ListShellSessions_Handler,
+		},
+		{
+			MethodName: "UploadShellFile",
+			Handler:    _DemoBackend_UploadShellFile_Handler,
+		},
+		{
+			MethodName: "ListShellFiles",
+			Handler:    _DemoBackend_ListShellFiles_Handler,
+		},
+		{
+			MethodName: "DeleteShellFile",
+			Handler:    _DemoBackend_DeleteShellFile_Handler,
+		},
+		{
+			MethodName: "GetShellWorkspace",
+			Handler:    _DemoBackend_GetShellWorkspace_Handler,
+		},
+		{
+			MethodName: "ResetShellWorkspace",
+			Handler:    _DemoBackend_ResetShellWorkspace_Handler,
+		},
+		{
+			MethodName: "ListShellProfiles",
+			Handler:    _DemoBackend_ListShellProfiles_Handler,
+		},
+		{
+			MethodName: "ListLiveShells",
+			Handler:    _DemoBackend_ListLiveShells_Handler,
+		},
+		{
+			MethodName: "KillShell",
+			Handler:    _DemoBackend_KillShell_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Updates",
+			Handler:       _DemoBackend_Updates_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Judge",
 			Handler:       _DemoBackend_Judge_Handler,
@@ -261,6 +1128,21 @@ var DemoBackend_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "FetchBlob",
+			Handler:       _DemoBackend_FetchBlob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetShellSession",
+			Handler:       _DemoBackend_GetShellSession_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetShellArchive",
+			Handler:       _DemoBackend_GetShellArchive_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "demo_backend.proto",
 }